@@ -5,6 +5,8 @@ import (
 	"context"
 	"sync"
 	"time"
+
+	"github.com/weiwenchen2022/utils/iterx"
 )
 
 // ErrTimeout is the error returned by SendTimed.
@@ -29,6 +31,11 @@ func (c Channel[E]) Recv(ctx context.Context, s []E) (n int, closed bool, err er
 	return Recv(ctx, c, s)
 }
 
+// All is a convenience method: c.All() returns iterx.FromChannel(c).
+func (c Channel[E]) All() iterx.Seq[E] {
+	return iterx.FromChannel(c)
+}
+
 // SendTimed is a convenience method: c.SendTimed(x, d) returns SendTimed(c, x, d).
 func (c Channel[E]) SendTimed(x E, d time.Duration) error {
 	return SendTimed(c, x, d)
@@ -47,6 +54,11 @@ func (c RecvOnlyChannel[E]) Recv(ctx context.Context, s []E) (n int, closed bool
 	return Recv(ctx, c, s)
 }
 
+// All is a convenience method: c.All() returns iterx.FromChannel(c).
+func (c RecvOnlyChannel[E]) All() iterx.Seq[E] {
+	return iterx.FromChannel(c)
+}
+
 // SliceToChannel returns a receive only channel of elements of s.
 // Channel is closed after s has been exhausted.
 func SliceToChannel[S ~[]E, E any](s S) <-chan E {
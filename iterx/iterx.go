@@ -0,0 +1,314 @@
+// Package iterx implements lazy push-style iterators and composable stages over them.
+//
+// An iterator is a function that calls yield with each element of a sequence in turn,
+// stopping early if yield returns false. Stages such as Filter and Map wrap an
+// iterator without running it, so a chain of stages does not allocate intermediate
+// slices; the elements only flow when a terminal operation such as Collect runs it.
+package iterx
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// Seq is an iterator over sequences of individual values.
+// It calls yield with each element in turn, and stops if yield returns false.
+type Seq[E any] func(yield func(E) bool)
+
+// Seq2 is an iterator over sequences of pairs of values.
+// It calls yield with each pair in turn, and stops if yield returns false.
+type Seq2[K, V any] func(yield func(K, V) bool)
+
+// FromSlice returns an iterator over the elements of the slice s, in order.
+func FromSlice[S ~[]E, E any](s S) Seq[E] {
+	return func(yield func(E) bool) {
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FromMap returns an iterator over the key-value pairs of the map m.
+// The iteration order is not specified and is not guaranteed to be the same
+// from one call to the next.
+func FromMap[M ~map[K]V, K comparable, V any](m M) Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range m {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// FromSet returns an iterator over the elements of the set s, such as a set.Set.
+// The iteration order is not specified and is not guaranteed to be the same
+// from one call to the next.
+func FromSet[S ~map[E]struct{}, E comparable](s S) Seq[E] {
+	return func(yield func(E) bool) {
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FromChannel returns an iterator over the elements received from the channel c
+// until it is closed. Since receiving consumes the elements, the iterator can
+// only be run once.
+func FromChannel[E any](c <-chan E) Seq[E] {
+	return func(yield func(E) bool) {
+		for v := range c {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns an iterator over the elements of seq satisfying f.
+func Filter[E any](seq Seq[E], f func(E) bool) Seq[E] {
+	return func(yield func(E) bool) {
+		seq(func(v E) bool {
+			return !f(v) || yield(v)
+		})
+	}
+}
+
+// Map returns an iterator over the results of applying f to the elements of seq.
+func Map[E, R any](seq Seq[E], f func(E) R) Seq[R] {
+	return func(yield func(R) bool) {
+		seq(func(v E) bool {
+			return yield(f(v))
+		})
+	}
+}
+
+// Take returns an iterator over the first n elements of seq,
+// or all of them if there are fewer than n.
+func Take[E any](seq Seq[E], n int) Seq[E] {
+	return func(yield func(E) bool) {
+		if n <= 0 {
+			return
+		}
+
+		i := 0
+		seq(func(v E) bool {
+			i++
+			return yield(v) && i < n
+		})
+	}
+}
+
+// Skip returns an iterator over the elements of seq after the first n.
+func Skip[E any](seq Seq[E], n int) Seq[E] {
+	return func(yield func(E) bool) {
+		i := 0
+		seq(func(v E) bool {
+			if i < n {
+				i++
+				return true
+			}
+			return yield(v)
+		})
+	}
+}
+
+// TakeWhile returns an iterator over the leading elements of seq satisfying f.
+func TakeWhile[E any](seq Seq[E], f func(E) bool) Seq[E] {
+	return func(yield func(E) bool) {
+		seq(func(v E) bool {
+			return f(v) && yield(v)
+		})
+	}
+}
+
+// SkipWhile returns an iterator over the elements of seq after the leading
+// elements satisfying f.
+func SkipWhile[E any](seq Seq[E], f func(E) bool) Seq[E] {
+	return func(yield func(E) bool) {
+		skipping := true
+		seq(func(v E) bool {
+			if skipping && f(v) {
+				return true
+			}
+			skipping = false
+			return yield(v)
+		})
+	}
+}
+
+// Enumerate returns an iterator over the index-element pairs of seq,
+// the indices counting from 0.
+func Enumerate[E any](seq Seq[E]) Seq2[int, E] {
+	return func(yield func(int, E) bool) {
+		i := 0
+		seq(func(v E) bool {
+			if !yield(i, v) {
+				return false
+			}
+			i++
+			return true
+		})
+	}
+}
+
+// Zip returns an iterator over the pairs of elements of a and b at the same position.
+// It stops when either a or b is exhausted.
+// Since b is run in a separate goroutine using Pull, it must be safe to do so;
+// if b panics, the iterator panics with a *PanicError.
+func Zip[A, B any](a Seq[A], b Seq[B]) Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, stop := Pull(b)
+		defer stop()
+
+		a(func(x A) bool {
+			y, ok := next()
+			return ok && yield(x, y)
+		})
+	}
+}
+
+// Keys returns an iterator over the first values of the pairs of seq.
+func Keys[K, V any](seq Seq2[K, V]) Seq[K] {
+	return func(yield func(K) bool) {
+		seq(func(k K, _ V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// Values returns an iterator over the second values of the pairs of seq.
+func Values[K, V any](seq Seq2[K, V]) Seq[V] {
+	return func(yield func(V) bool) {
+		seq(func(_ K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Collect runs seq and returns a new slice of its elements,
+// or nil if there are none.
+func Collect[E any](seq Seq[E]) []E {
+	var s []E
+	seq(func(v E) bool {
+		s = append(s, v)
+		return true
+	})
+	return s
+}
+
+// CollectMap runs seq and returns a new map of its key-value pairs.
+// If a key appears several times, the last value is kept.
+func CollectMap[K comparable, V any](seq Seq2[K, V]) map[K]V {
+	m := make(map[K]V)
+	seq(func(k K, v V) bool {
+		m[k] = v
+		return true
+	})
+	return m
+}
+
+// PanicError is the value re-panicked on the calling goroutine of next
+// when the iterator passed to Pull panics in its goroutine.
+type PanicError struct {
+	// Value is the value the iterator panicked with.
+	Value any
+
+	// Stack is the stack trace of the iterator goroutine at the time of the panic.
+	Stack []byte
+}
+
+// Error returns the panic value followed by the stack trace of the iterator goroutine.
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("%v\n\niterator goroutine stack:\n%s", p.Value, p.Stack)
+}
+
+// Unwrap returns the panic value if it is an error, or nil otherwise.
+func (p *PanicError) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
+}
+
+// pullValue is an element sent by the goroutine of Pull, or the panic ending it.
+type pullValue[E any] struct {
+	v     E
+	panic *PanicError
+}
+
+// Pull converts the push-style iterator seq into a pull-style iterator:
+// each call to next returns the next element of seq and true,
+// or the zero value and false once seq is exhausted.
+// The caller must call stop when it no longer needs elements. It is safe
+// to call stop several times.
+// Pull runs seq in a separate goroutine, started on the first call to next;
+// next and stop must not be called concurrently.
+// stop does not wait for that goroutine: seq returns when it next yields, so if it
+// is blocked meanwhile, for example receiving from a channel which is never closed,
+// the goroutine only exits once seq is unblocked.
+// If seq panics, next panics with a *PanicError on the calling goroutine.
+func Pull[E any](seq Seq[E]) (next func() (E, bool), stop func()) {
+	var (
+		values  = make(chan pullValue[E])
+		done    = make(chan struct{})
+		started bool
+		stopped bool
+		once    sync.Once
+	)
+
+	next = func() (E, bool) {
+		if stopped {
+			return *new(E), false
+		}
+		if !started {
+			started = true
+			go func() {
+				defer close(values)
+				panicked := true
+				defer func() {
+					if panicked {
+						// recover returns nil for panic(nil), so rely on the flag instead.
+						p := &PanicError{Value: recover(), Stack: debug.Stack()}
+						select {
+						case values <- pullValue[E]{panic: p}:
+						case <-done:
+						}
+					}
+				}()
+
+				seq(func(v E) bool {
+					select {
+					case values <- pullValue[E]{v: v}:
+						return true
+					case <-done:
+						return false
+					}
+				})
+				panicked = false
+			}()
+		}
+
+		r, ok := <-values
+		switch {
+		case r.panic != nil:
+			stopped = true
+			panic(r.panic)
+		case !ok:
+			stopped = true
+		}
+		return r.v, ok
+	}
+
+	stop = func() {
+		once.Do(func() {
+			stopped = true
+			close(done)
+		})
+	}
+
+	return next, stop
+}
@@ -0,0 +1,245 @@
+package iterx_test
+
+import (
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/weiwenchen2022/utils/channels"
+	. "github.com/weiwenchen2022/utils/iterx"
+	"github.com/weiwenchen2022/utils/maps"
+	"github.com/weiwenchen2022/utils/set"
+	"github.com/weiwenchen2022/utils/slices"
+)
+
+func count(n int) Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func isEven(v int) bool { return v%2 == 0 }
+
+func TestAdapters(t *testing.T) {
+	t.Parallel()
+
+	s := slices.NewSlice([]int{1, 2, 3})
+	if got := Collect(s.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Collect(Slice.All()) = %v, want [1 2 3]", got)
+	}
+	if got := Collect(FromSlice([]int(nil))); got != nil {
+		t.Errorf("Collect(FromSlice(nil)) = %#v, want nil", got)
+	}
+
+	m := maps.NewMap(map[string]int{"a": 1, "b": 2})
+	if got := CollectMap(m.All()); !maps.Equal(got, m) {
+		t.Errorf("CollectMap(Map.All()) = %v, want %v", got, m)
+	}
+	keys := Collect(Keys(m.All()))
+	sort.Strings(keys)
+	if !slices.Equal(keys, []string{"a", "b"}) {
+		t.Errorf("Keys(Map.All()) = %v, want [a b]", keys)
+	}
+	values := Collect(Values(FromMap(map[string]int{"a": 1})))
+	if !slices.Equal(values, []int{1}) {
+		t.Errorf("Values(FromMap({a: 1})) = %v, want [1]", values)
+	}
+
+	st := set.New(1, 2, 3)
+	elems := Collect(st.All())
+	sort.Ints(elems)
+	if !slices.Equal(elems, []int{1, 2, 3}) {
+		t.Errorf("Collect(Set.All()) = %v, want [1 2 3]", elems)
+	}
+
+	c := channels.NewRecvOnlyChannel(channels.SliceToChannel([]int{1, 2, 3}))
+	if got := Collect(c.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Collect(RecvOnlyChannel.All()) = %v, want [1 2 3]", got)
+	}
+}
+
+func TestStages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		seq  Seq[int]
+		want []int
+	}{
+		{"Filter", Filter(count(10), isEven), []int{0, 2, 4, 6, 8}},
+		{"Map", Map(count(4), func(v int) int { return v * v }), []int{0, 1, 4, 9}},
+		{"Take", Take(count(10), 3), []int{0, 1, 2}},
+		{"Take more", Take(count(2), 3), []int{0, 1}},
+		{"Take 0", Take(count(10), 0), nil},
+		{"Skip", Skip(count(5), 3), []int{3, 4}},
+		{"Skip more", Skip(count(2), 3), nil},
+		{"TakeWhile", TakeWhile(count(10), func(v int) bool { return v < 4 }), []int{0, 1, 2, 3}},
+		{"SkipWhile", SkipWhile(FromSlice([]int{1, 2, 5, 1}), func(v int) bool { return v < 3 }), []int{5, 1}},
+		{"chain", Take(Map(Filter(count(100), isEven), func(v int) int { return v + 1 }), 3), []int{1, 3, 5}},
+	}
+	for _, tc := range tests {
+		if got := Collect(tc.seq); !slices.Equal(got, tc.want) {
+			t.Errorf("%s: Collect = %v, want %v", tc.name, got, tc.want)
+		}
+		// Iterators over slices can be run several times.
+		if got := Collect(tc.seq); !slices.Equal(got, tc.want) {
+			t.Errorf("%s: second Collect = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	// Take stops the underlying iterator as soon as it has enough elements.
+	pulled := 0
+	Collect(Take(Map(count(100), func(v int) int { pulled++; return v }), 5))
+	if pulled != 5 {
+		t.Errorf("Take(5) pulled %d elements, want 5", pulled)
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	t.Parallel()
+
+	var got []int
+	Enumerate(FromSlice([]int{10, 20, 30, 40}))(func(i, v int) bool {
+		got = append(got, i, v)
+		return i < 2
+	})
+	if want := []int{0, 10, 1, 20, 2, 30}; !slices.Equal(got, want) {
+		t.Errorf("Enumerate = %v, want %v", got, want)
+	}
+}
+
+func TestZip(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	Zip(FromSlice([]int{1, 2, 3}), FromSlice([]string{"a", "b"}))(func(i int, s string) bool {
+		got = append(got, s)
+		return true
+	})
+	if !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Zip = %v, want [a b]", got)
+	}
+
+	m := CollectMap(Zip(FromSlice([]string{"x", "y"}), count(100)))
+	if !maps.Equal(m, map[string]int{"x": 0, "y": 1}) {
+		t.Errorf("CollectMap(Zip) = %v, want map[x:0 y:1]", m)
+	}
+}
+
+func TestZip_UnclosedChannel(t *testing.T) {
+	t.Parallel()
+
+	c := make(chan int, 1)
+	c <- 20
+
+	done := make(chan map[int]int)
+	go func() {
+		done <- CollectMap(Zip(FromSlice([]int{10}), FromChannel(c)))
+	}()
+
+	select {
+	case m := <-done:
+		if !maps.Equal(m, map[int]int{10: 20}) {
+			t.Errorf("CollectMap(Zip) = %v, want map[10:20]", m)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Zip against an unclosed channel did not return")
+	}
+}
+
+func TestPull(t *testing.T) {
+	t.Parallel()
+
+	next, stop := Pull(count(3))
+	for want := 0; want < 3; want++ {
+		if v, ok := next(); v != want || !ok {
+			t.Errorf("next() = %v, %t, want %v, true", v, ok, want)
+		}
+	}
+	if v, ok := next(); ok {
+		t.Errorf("next() after the end = %v, true, want false", v)
+	}
+	stop()
+
+	// Stopping early makes the iterator return.
+	next, stop = Pull(count(1000))
+	next()
+	stop()
+	stop()
+	if v, ok := next(); ok {
+		t.Errorf("next() after stop = %v, true, want false", v)
+	}
+
+	// A panic of the iterator is raised by next.
+	errBoom := errors.New("boom")
+	next, stop = Pull(Seq[int](func(yield func(int) bool) {
+		yield(0)
+		panic(errBoom)
+	}))
+	defer stop()
+	next()
+	func() {
+		defer func() {
+			if p, ok := recover().(*PanicError); !ok || !errors.Is(p, errBoom) {
+				t.Errorf("next() panicked with %v, want a *PanicError wrapping boom", p)
+			}
+		}()
+		next()
+		t.Errorf("next() did not panic")
+	}()
+	if _, ok := next(); ok {
+		t.Errorf("next() after a panic = true, want false")
+	}
+
+	// panic(nil) is not taken for the end of the iterator.
+	next, stop = Pull(Seq[int](func(yield func(int) bool) {
+		panic(nil)
+	}))
+	defer stop()
+	func() {
+		defer func() {
+			if _, ok := recover().(*PanicError); !ok {
+				t.Errorf("next() did not panic with a *PanicError for panic(nil)")
+			}
+		}()
+		next()
+	}()
+
+	// Stopping before starting does not run the iterator.
+	_, stop = Pull(Seq[int](func(func(int) bool) { t.Errorf("iterator ran") }))
+	stop()
+}
+
+func BenchmarkPipeline(b *testing.B) {
+	s := make([]int, 10_000)
+	for i := range s {
+		s[i] = i
+	}
+
+	b.Run("iterx", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			Map(Filter(FromSlice(s), isEven), func(v int) int { return v * v })(func(v int) bool {
+				sum += v
+				return true
+			})
+		}
+	})
+
+	b.Run("slices", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			even := slices.Filter(s, func(_ int, v int) bool { return isEven(v) })
+			for _, v := range slices.Map(even, func(_ int, v int) int { return v * v }) {
+				sum += v
+			}
+		}
+	})
+}
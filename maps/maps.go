@@ -5,6 +5,8 @@
 // Package maps defines various types and functions useful with maps of any type.
 package maps
 
+import "github.com/weiwenchen2022/utils/iterx"
+
 // Map attaches the common methods to map[K]V
 type Map[K comparable, V any] map[K]V
 
@@ -23,6 +25,11 @@ func (m Map[K, V]) Values() []V {
 	return Values(m)
 }
 
+// All is a convenience method: m.All() returns iterx.FromMap(m).
+func (m Map[K, V]) All() iterx.Seq2[K, V] {
+	return iterx.FromMap(m)
+}
+
 // EqualFunc is a convenience method: m.EqualFunc(m2, eq) returns EqualFunc(m, m2, eq).
 func (m Map[K, V]) EqualFunc(m2 map[K]V, eq func(V, V) bool) bool {
 	return EqualFunc(m, m2, eq)
@@ -66,6 +73,11 @@ func (m ComparableMap[K, V]) Values() []V {
 	return Values(m)
 }
 
+// All is a convenience method: m.All() returns iterx.FromMap(m).
+func (m ComparableMap[K, V]) All() iterx.Seq2[K, V] {
+	return iterx.FromMap(m)
+}
+
 // Equal is a convenience method: m.Equal(m2) returns Equal(m, m2).
 func (m ComparableMap[K, V]) Equal(m2 map[K]V) bool {
 	return Equal(m, m2)
@@ -4,6 +4,8 @@ package set
 import (
 	"fmt"
 	"strings"
+
+	"github.com/weiwenchen2022/utils/iterx"
 )
 
 var emptyStruct = struct{}{}
@@ -139,6 +141,11 @@ func (s Set[E]) AppendTo(slice []E) []E {
 	return slice
 }
 
+// All returns a lazy iterator over the elements of s, in unspecified order.
+func (s Set[E]) All() iterx.Seq[E] {
+	return iterx.FromSet(s)
+}
+
 // Elems returns the slice of the elements of s.
 func (s Set[E]) Elems() []E {
 	return s.AppendTo(nil)
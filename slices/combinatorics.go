@@ -0,0 +1,185 @@
+package slices
+
+import "golang.org/x/exp/constraints"
+
+// NextPermutation rearranges the slice s into the lexicographically next greater
+// permutation of its elements, and reports whether there was one. If s is already
+// the greatest permutation, NextPermutation rearranges it into the smallest one,
+// that is sorted in ascending order, and returns false.
+// Starting from a sorted slice, repeated calls visit every distinct permutation once.
+// For floating-point numbers, NaNs are ordered before other values.
+func NextPermutation[S ~[]E, E constraints.Ordered](s S) bool {
+	return NextPermutationFunc(s, cmpCompare[E])
+}
+
+// NextPermutationFunc is like NextPermutation but uses a comparison function.
+func NextPermutationFunc[S ~[]E, E any](s S, cmp func(a, b E) int) bool {
+	// Find the longest non-increasing suffix s[i:].
+	i := len(s) - 1
+	for i > 0 && cmp(s[i-1], s[i]) >= 0 {
+		i--
+	}
+	if i <= 0 {
+		Reverse(s)
+		return false
+	}
+
+	// Swap the pivot s[i-1] with the rightmost element greater than it,
+	// then make the suffix ascending.
+	j := len(s) - 1
+	for cmp(s[j], s[i-1]) <= 0 {
+		j--
+	}
+	s[i-1], s[j] = s[j], s[i-1]
+	Reverse(s[i:])
+	return true
+}
+
+// Permutations calls yield for each of the len(s)! orderings of the elements of
+// the slice s, in lexicographic order of their positions in s, until yield returns false.
+// Elements are treated as distinct even if they are equal; use NextPermutation
+// to visit distinct permutations only.
+// The permutations are generated lazily into a single slice, which is reused
+// between calls: yield must not modify it nor retain it after returning,
+// and should Clone it if needed.
+func Permutations[S ~[]E, E any](s S, yield func(S) bool) {
+	idx := make([]int, len(s))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	p := Clone(s)
+	if p == nil {
+		p = S{}
+	}
+	for {
+		for i, j := range idx {
+			p[i] = s[j]
+		}
+		if !yield(p) || !NextPermutation(idx) {
+			return
+		}
+	}
+}
+
+// Combinations calls yield for each way of choosing k elements of the slice s,
+// keeping their relative order, in lexicographic order of their positions in s,
+// until yield returns false. There are len(s)!/(k!(len(s)-k)!) of them.
+// If k is 0, yield is called once with an empty slice;
+// if k < 0 or k > len(s), it is not called.
+// As with Permutations, the combinations are generated into a single slice,
+// which is reused between calls.
+func Combinations[S ~[]E, E any](s S, k int, yield func(S) bool) {
+	n := len(s)
+	if k < 0 || k > n {
+		return
+	}
+
+	idx := make([]int, k)
+	for i := range idx {
+		idx[i] = i
+	}
+
+	c := make(S, k)
+	for {
+		for i, j := range idx {
+			c[i] = s[j]
+		}
+		if !yield(c) {
+			return
+		}
+
+		// Advance the rightmost index that can still move right,
+		// and reset the indices after it.
+		i := k - 1
+		for i >= 0 && idx[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+
+		idx[i]++
+		for j := i + 1; j < k; j++ {
+			idx[j] = idx[j-1] + 1
+		}
+	}
+}
+
+// CartesianProduct calls yield for each tuple made of one element of each slice of ss,
+// in lexicographic order of the positions, the last slice varying fastest,
+// until yield returns false. If ss is empty, yield is called once with an empty
+// tuple; if any slice of ss is empty, it is not called.
+// As with Permutations, the tuples are generated into a single slice,
+// which is reused between calls.
+func CartesianProduct[S ~[]E, E any](ss []S, yield func(S) bool) {
+	for _, s := range ss {
+		if len(s) == 0 {
+			return
+		}
+	}
+
+	idx := make([]int, len(ss))
+	t := make(S, len(ss))
+	for i, s := range ss {
+		t[i] = s[0]
+	}
+	for {
+		if !yield(t) {
+			return
+		}
+
+		// Increment idx like an odometer.
+		i := len(ss) - 1
+		for ; i >= 0; i-- {
+			idx[i]++
+			if idx[i] < len(ss[i]) {
+				t[i] = ss[i][idx[i]]
+				break
+			}
+
+			idx[i] = 0
+			t[i] = ss[i][0]
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
+
+// Convenience wrappers for common cases.
+
+// NextPermutationFunc returns the result of applying NextPermutationFunc to the receiver and cmp.
+func (s Slice[E]) NextPermutationFunc(cmp func(a, b E) int) bool {
+	return NextPermutationFunc(s, cmp)
+}
+
+// Permutations applies Permutations to the receiver and yield.
+func (s Slice[E]) Permutations(yield func(Slice[E]) bool) {
+	Permutations(s, yield)
+}
+
+// Combinations applies Combinations to the receiver, k and yield.
+func (s Slice[E]) Combinations(k int, yield func(Slice[E]) bool) {
+	Combinations(s, k, yield)
+}
+
+// NextPermutationFunc returns the result of applying NextPermutationFunc to the receiver and cmp.
+func (s ComparableSlice[E]) NextPermutationFunc(cmp func(a, b E) int) bool {
+	return NextPermutationFunc(s, cmp)
+}
+
+// Permutations applies Permutations to the receiver and yield.
+func (s ComparableSlice[E]) Permutations(yield func(ComparableSlice[E]) bool) {
+	Permutations(s, yield)
+}
+
+// Combinations applies Combinations to the receiver, k and yield.
+func (s ComparableSlice[E]) Combinations(k int, yield func(ComparableSlice[E]) bool) {
+	Combinations(s, k, yield)
+}
+
+// NextPermutation returns the result of applying NextPermutation to the receiver.
+func (s OrderedSlice[E]) NextPermutation() bool {
+	return NextPermutation(s)
+}
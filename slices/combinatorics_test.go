@@ -0,0 +1,199 @@
+package slices_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/weiwenchen2022/utils/channels"
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+func TestNextPermutation(t *testing.T) {
+	t.Parallel()
+
+	s := []int{1, 2, 2, 3}
+	var got []string
+	for {
+		got = append(got, fmt.Sprint(s))
+		if !NextPermutation(s) {
+			break
+		}
+	}
+	want := []string{
+		"[1 2 2 3]", "[1 2 3 2]", "[1 3 2 2]",
+		"[2 1 2 3]", "[2 1 3 2]", "[2 2 1 3]", "[2 2 3 1]", "[2 3 1 2]", "[2 3 2 1]",
+		"[3 1 2 2]", "[3 2 1 2]", "[3 2 2 1]",
+	}
+	if !Equal(got, want) {
+		t.Errorf("NextPermutation visited %v, want %v", got, want)
+	}
+	if !Equal(s, []int{1, 2, 2, 3}) {
+		t.Errorf("NextPermutation after the last permutation = %v, want [1 2 2 3]", s)
+	}
+
+	for _, s := range [][]int{nil, {}, {1}} {
+		if NextPermutation(s) {
+			t.Errorf("NextPermutation(%v) = true, want false", s)
+		}
+	}
+
+	r := []int{3, 2, 1}
+	if !NextPermutationFunc(r, func(a, b int) int { return cmp(b, a) }) || !Equal(r, []int{3, 1, 2}) {
+		t.Errorf("NextPermutationFunc([3 2 1], reversed) = %v, want [3 1 2]", r)
+	}
+}
+
+func TestPermutations(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	Permutations([]byte("abc"), func(p []byte) bool {
+		got = append(got, string(p))
+		return true
+	})
+	if want := []string{"abc", "acb", "bac", "bca", "cab", "cba"}; !Equal(got, want) {
+		t.Errorf("Permutations(abc) = %v, want %v", got, want)
+	}
+
+	got = got[:0]
+	Permutations([]byte("aa"), func(p []byte) bool {
+		got = append(got, string(p))
+		return true
+	})
+	if want := []string{"aa", "aa"}; !Equal(got, want) {
+		t.Errorf("Permutations(aa) = %v, want %v", got, want)
+	}
+
+	calls := 0
+	Permutations([]int(nil), func(p []int) bool {
+		calls++
+		if len(p) != 0 {
+			t.Errorf("Permutations(nil) yielded %v, want []", p)
+		}
+		return true
+	})
+	if calls != 1 {
+		t.Errorf("Permutations(nil) yielded %d times, want 1", calls)
+	}
+
+	calls = 0
+	Permutations(sliceGenerator(20), func([]int) bool {
+		calls++
+		return calls < 5
+	})
+	if calls != 5 {
+		t.Errorf("Permutations stopped after %d calls, want 5", calls)
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		s    string
+		k    int
+		want []string
+	}{
+		{"abcd", 2, []string{"ab", "ac", "ad", "bc", "bd", "cd"}},
+		{"abcd", 4, []string{"abcd"}},
+		{"abcd", 0, []string{""}},
+		{"abcd", 5, nil},
+		{"abcd", -1, nil},
+		{"", 0, []string{""}},
+	}
+	for _, tc := range tests {
+		var got []string
+		Combinations([]byte(tc.s), tc.k, func(c []byte) bool {
+			got = append(got, string(c))
+			return true
+		})
+		if !Equal(got, tc.want) {
+			t.Errorf("Combinations(%q, %d) = %v, want %v", tc.s, tc.k, got, tc.want)
+		}
+	}
+
+	calls := 0
+	Combinations(sliceGenerator(50), 25, func([]int) bool {
+		calls++
+		return false
+	})
+	if calls != 1 {
+		t.Errorf("Combinations stopped after %d calls, want 1", calls)
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ss   [][]byte
+		want []string
+	}{
+		{[][]byte{[]byte("ab"), []byte("xyz")}, []string{"ax", "ay", "az", "bx", "by", "bz"}},
+		{[][]byte{[]byte("a"), []byte("b"), []byte("cd")}, []string{"abc", "abd"}},
+		{[][]byte{[]byte("ab"), nil}, nil},
+		{nil, []string{""}},
+	}
+	for _, tc := range tests {
+		var got []string
+		CartesianProduct(tc.ss, func(t []byte) bool {
+			got = append(got, string(t))
+			return true
+		})
+		if !Equal(got, tc.want) {
+			t.Errorf("CartesianProduct(%q) = %v, want %v", tc.ss, got, tc.want)
+		}
+	}
+
+	calls := 0
+	CartesianProduct([][]int{{1, 2}, {3, 4}}, func([]int) bool {
+		calls++
+		return calls < 3
+	})
+	if calls != 3 {
+		t.Errorf("CartesianProduct stopped after %d calls, want 3", calls)
+	}
+}
+
+func TestPermutations_Generator(t *testing.T) {
+	t.Parallel()
+
+	c := channels.Generator(func(yield func(string)) {
+		Permutations(strings.Split("xyz", ""), func(p []string) bool {
+			yield(strings.Join(p, ""))
+			return true
+		})
+	})
+	if got := channels.ChannelToSlice(c); len(got) != 6 || got[5] != "zyx" {
+		t.Errorf("Permutations through Generator = %v, want 6 permutations ending with zyx", got)
+	}
+}
+
+// Tests for convenience wrappers.
+
+func TestOrderedSlice_NextPermutation(t *testing.T) {
+	t.Parallel()
+
+	s := NewOrderedSlice([]int{1, 3, 2})
+	if !s.NextPermutation() || !Equal(*s, []int{2, 1, 3}) {
+		t.Errorf("[1 3 2].NextPermutation() = %v, want [2 1 3]", *s)
+	}
+
+	n := 0
+	NewSlice([]int{1, 2, 3, 4}).Combinations(3, func(Slice[int]) bool {
+		n++
+		return true
+	})
+	if n != 4 {
+		t.Errorf("[1 2 3 4].Combinations(3) yielded %d times, want 4", n)
+	}
+}
+
+func BenchmarkPermutations(b *testing.B) {
+	s := sliceGenerator(8)
+
+	for i := 0; i < b.N; i++ {
+		Permutations(s, func([]int) bool { return true })
+	}
+}
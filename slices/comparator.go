@@ -0,0 +1,98 @@
+package slices
+
+import "golang.org/x/exp/constraints"
+
+// Comparator is a comparison function returning a negative number when a < b,
+// a positive number when a > b and zero when a and b are equivalent.
+// It can be passed wherever a comparison function is expected, such as
+// to SortFunc, BinarySearchFunc or CompareFunc, and combined with the
+// methods below and ThenBy to build multi-key orderings:
+//
+//	SortFunc(people, ThenBy(By(func(p Person) string { return p.Last }),
+//		func(p Person) int { return p.Age }).Reverse())
+type Comparator[E any] func(a, b E) int
+
+// By returns a Comparator ordering elements by the key extracted by key.
+// For floating-point keys, NaNs are ordered before other values.
+func By[E any, K constraints.Ordered](key func(E) K) Comparator[E] {
+	return func(a, b E) int {
+		return cmpCompare(key(a), key(b))
+	}
+}
+
+// ByFunc returns a Comparator ordering elements by the key extracted by key,
+// compared using cmp.
+func ByFunc[E, K any](key func(E) K, cmp func(a, b K) int) Comparator[E] {
+	return func(a, b E) int {
+		return cmp(key(a), key(b))
+	}
+}
+
+// ThenBy returns a Comparator ordering elements by c, then the elements
+// that c finds equivalent by the key extracted by key.
+func ThenBy[E any, K constraints.Ordered](c Comparator[E], key func(E) K) Comparator[E] {
+	return c.Then(By(key))
+}
+
+// Then returns a Comparator ordering elements by c, then the elements
+// that c finds equivalent by next.
+func (c Comparator[E]) Then(next func(a, b E) int) Comparator[E] {
+	return func(a, b E) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		return next(a, b)
+	}
+}
+
+// Reverse returns a Comparator imposing the reverse ordering of c.
+func (c Comparator[E]) Reverse() Comparator[E] {
+	return func(a, b E) int {
+		return c(b, a)
+	}
+}
+
+// Equal reports whether a and b are equivalent according to c.
+// The method value c.Equal can be passed to functions expecting
+// an equality function, such as CompactFunc or IndexFunc.
+func (c Comparator[E]) Equal(a, b E) bool {
+	return c(a, b) == 0
+}
+
+// Less reports whether a is ordered before b according to c.
+func (c Comparator[E]) Less(a, b E) bool {
+	return c(a, b) < 0
+}
+
+// NilsFirst returns a Comparator of pointers ordering nil before non-nil pointers,
+// and non-nil pointers by comparing the values they point to using cmp.
+func NilsFirst[T any](cmp func(a, b T) int) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		case b == nil:
+			return +1
+		default:
+			return cmp(*a, *b)
+		}
+	}
+}
+
+// NilsLast is like NilsFirst but orders nil after non-nil pointers.
+func NilsLast[T any](cmp func(a, b T) int) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return +1
+		case b == nil:
+			return -1
+		default:
+			return cmp(*a, *b)
+		}
+	}
+}
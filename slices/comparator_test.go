@@ -0,0 +1,77 @@
+package slices_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+type person struct {
+	first, last string
+	age         int
+	score       *float64
+}
+
+func newFloat(f float64) *float64 { return &f }
+
+func TestComparator(t *testing.T) {
+	t.Parallel()
+
+	people := []person{
+		{"Alice", "Smith", 30, newFloat(1)},
+		{"Bob", "Jones", 25, nil},
+		{"Carol", "Smith", 25, newFloat(3)},
+		{"Dave", "Jones", 25, newFloat(2)},
+		{"Eve", "Adams", 40, nil},
+	}
+	firsts := func(s []person) string {
+		var b strings.Builder
+		for _, p := range s {
+			b.WriteString(p.first[:1])
+		}
+		return b.String()
+	}
+
+	byLast := By(func(p person) string { return p.last })
+	tests := []struct {
+		name string
+		cmp  Comparator[person]
+		want string
+	}{
+		{"By", byLast, "EBDAC"},
+		{"ThenBy", ThenBy(byLast, func(p person) int { return p.age }), "EBDCA"},
+		{"Then", byLast.Then(By(func(p person) string { return p.first }).Reverse()), "EDBCA"},
+		{"Reverse", ThenBy(By(func(p person) int { return p.age }), func(p person) string { return p.first }).Reverse(), "EADCB"},
+		{"NilsFirst", ByFunc(func(p person) *float64 { return p.score }, NilsFirst(cmp[float64])), "BEADC"},
+		{"NilsLast", ByFunc(func(p person) *float64 { return p.score }, NilsLast(cmp[float64])), "ADCBE"},
+	}
+	for _, tc := range tests {
+		s := Clone(people)
+		SortStableFunc(s, tc.cmp)
+		if got := firsts(s); got != tc.want {
+			t.Errorf("%s: sorted = %s, want %s", tc.name, got, tc.want)
+		}
+		if !IsSortedFunc(s, tc.cmp) {
+			t.Errorf("%s: IsSortedFunc = false, want true", tc.name)
+		}
+	}
+
+	byAge := By(func(p person) int { return p.age })
+	if !byAge.Equal(people[1], people[2]) || byAge.Equal(people[0], people[1]) {
+		t.Errorf("Equal: wrong result")
+	}
+	if !byAge.Less(people[1], people[0]) || byAge.Less(people[0], people[1]) {
+		t.Errorf("Less: wrong result")
+	}
+
+	s := Clone(people)
+	SortStableFunc(s, byAge)
+	if got := firsts(CompactFunc(s, byAge.Equal)); got != "BAE" {
+		t.Errorf("CompactFunc(byAge.Equal) = %s, want BAE", got)
+	}
+
+	if got := CompareFunc(people[:2], people[:3], byLast); got != -1 {
+		t.Errorf("CompareFunc(byLast) = %d, want -1", got)
+	}
+}
@@ -0,0 +1,190 @@
+package slices
+
+// Deque is a double-ended queue backed by a ring buffer, so pushing and popping
+// at both ends run in amortized constant time. The zero value is an empty deque
+// ready to use, which grows as needed.
+// A deque created by NewBoundedDeque has a fixed capacity instead: pushing onto
+// a full bounded deque overwrites the element at the opposite end.
+type Deque[E any] struct {
+	buf     []E
+	head    int // index of the front element in buf
+	n       int // number of elements
+	bounded bool
+}
+
+// NewDeque creates and initializes a new growable Deque using s as its initial
+// contents, s[0] being the front. The new Deque takes ownership of s, and the
+// caller should not use s after this call.
+func NewDeque[E any](s []E) *Deque[E] {
+	return &Deque[E]{buf: s[:cap(s)], n: len(s)}
+}
+
+// NewBoundedDeque returns a new empty Deque with the fixed capacity.
+// It panics if capacity < 1.
+func NewBoundedDeque[E any](capacity int) *Deque[E] {
+	if capacity < 1 {
+		panic("slices: NewBoundedDeque capacity cannot be less than 1")
+	}
+	return &Deque[E]{buf: make([]E, capacity), bounded: true}
+}
+
+// Len returns the number of elements in the deque d.
+func (d *Deque[E]) Len() int {
+	return d.n
+}
+
+// Cap returns the capacity of the deque d.
+func (d *Deque[E]) Cap() int {
+	return len(d.buf)
+}
+
+// At returns the i'th element of the deque d, the front element being at index 0.
+// It panics if i is out of range.
+func (d *Deque[E]) At(i int) E {
+	return d.buf[d.index(i)]
+}
+
+// Set sets the i'th element of the deque d to v.
+// It panics if i is out of range.
+func (d *Deque[E]) Set(i int, v E) {
+	d.buf[d.index(i)] = v
+}
+
+// PushBack adds v at the back of the deque d. If d is bounded and full,
+// its front element is overwritten.
+func (d *Deque[E]) PushBack(v E) {
+	if d.n == len(d.buf) {
+		if d.bounded {
+			d.buf[d.head] = v
+			d.head = d.wrap(d.head + 1)
+			return
+		}
+		d.grow()
+	}
+
+	d.buf[d.wrap(d.head+d.n)] = v
+	d.n++
+}
+
+// PushFront adds v at the front of the deque d. If d is bounded and full,
+// its back element is overwritten.
+func (d *Deque[E]) PushFront(v E) {
+	if d.n == len(d.buf) {
+		if d.bounded {
+			d.head = d.wrap(d.head + len(d.buf) - 1)
+			d.buf[d.head] = v
+			return
+		}
+		d.grow()
+	}
+
+	d.head = d.wrap(d.head + len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.n++
+}
+
+// PopFront removes and returns the front element of the deque d,
+// and reports whether d was non-empty.
+func (d *Deque[E]) PopFront() (E, bool) {
+	var zero E
+	if d.n == 0 {
+		return zero, false
+	}
+
+	v := d.buf[d.head]
+	d.buf[d.head] = zero // let the element be garbage collected
+	d.head = d.wrap(d.head + 1)
+	d.n--
+	return v, true
+}
+
+// PopBack removes and returns the back element of the deque d,
+// and reports whether d was non-empty.
+func (d *Deque[E]) PopBack() (E, bool) {
+	var zero E
+	if d.n == 0 {
+		return zero, false
+	}
+
+	i := d.wrap(d.head + d.n - 1)
+	v := d.buf[i]
+	d.buf[i] = zero // let the element be garbage collected
+	d.n--
+	return v, true
+}
+
+// Front returns the front element of the deque d, and reports whether d is non-empty.
+func (d *Deque[E]) Front() (E, bool) {
+	if d.n == 0 {
+		return *new(E), false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back element of the deque d, and reports whether d is non-empty.
+func (d *Deque[E]) Back() (E, bool) {
+	if d.n == 0 {
+		return *new(E), false
+	}
+	return d.buf[d.wrap(d.head+d.n-1)], true
+}
+
+// Clear removes all the elements of the deque d, keeping its capacity.
+func (d *Deque[E]) Clear() {
+	zeroSlice(d.buf)
+	d.head, d.n = 0, 0
+}
+
+// Slice returns a new Slice of the elements of the deque d, from front to back.
+func (d *Deque[E]) Slice() Slice[E] {
+	s := make(Slice[E], d.n)
+	d.copyTo(s)
+	return s
+}
+
+// index returns the position in d.buf of the i'th element.
+func (d *Deque[E]) index(i int) int {
+	if uint(i) >= uint(d.n) {
+		panic("slices: Deque index out of range")
+	}
+	return d.wrap(d.head + i)
+}
+
+// wrap returns i modulo len(d.buf), for 0 <= i < 2*len(d.buf).
+func (d *Deque[E]) wrap(i int) int {
+	if i >= len(d.buf) {
+		i -= len(d.buf)
+	}
+	return i
+}
+
+// copyTo copies the elements of d, from front to back, to s.
+func (d *Deque[E]) copyTo(s []E) {
+	if d.head+d.n <= len(d.buf) {
+		copy(s, d.buf[d.head:d.head+d.n])
+		return
+	}
+
+	k := copy(s, d.buf[d.head:])
+	copy(s[k:], d.buf[:d.n-k])
+}
+
+// grow doubles the capacity of the ring buffer, moving the front element to index 0.
+func (d *Deque[E]) grow() {
+	c := 2 * len(d.buf)
+	if c < 8 {
+		c = 8
+	}
+
+	buf := make([]E, c)
+	d.copyTo(buf)
+	d.buf, d.head = buf, 0
+}
+
+// Convenience wrappers for common cases.
+
+// Deque returns a new growable Deque holding a copy of the elements of the receiver,
+// the first one being the front.
+func (s Slice[E]) Deque() *Deque[E] {
+	return NewDeque(Clone([]E(s)))
+}
@@ -0,0 +1,167 @@
+package slices_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+func TestDeque(t *testing.T) {
+	t.Parallel()
+
+	var d Deque[int]
+	if _, ok := d.PopFront(); ok {
+		t.Errorf("PopFront() on empty deque: got true, want false")
+	}
+	if _, ok := d.PopBack(); ok {
+		t.Errorf("PopBack() on empty deque: got true, want false")
+	}
+	if _, ok := d.Front(); ok {
+		t.Errorf("Front() on empty deque: got true, want false")
+	}
+
+	for i := 0; i < 10; i++ {
+		d.PushBack(i)
+		d.PushFront(-i - 1)
+	}
+	want := []int{-10, -9, -8, -7, -6, -5, -4, -3, -2, -1, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if got := d.Slice(); !Equal(got, want) || d.Len() != 20 {
+		t.Errorf("Slice() = %v, want %v", got, want)
+	}
+	for i, v := range want {
+		if got := d.At(i); got != v {
+			t.Errorf("At(%d) = %d, want %d", i, got, v)
+		}
+	}
+	if v, _ := d.Front(); v != -10 {
+		t.Errorf("Front() = %d, want -10", v)
+	}
+	if v, _ := d.Back(); v != 9 {
+		t.Errorf("Back() = %d, want 9", v)
+	}
+
+	if v, ok := d.PopFront(); v != -10 || !ok {
+		t.Errorf("PopFront() = %d, %t, want -10, true", v, ok)
+	}
+	if v, ok := d.PopBack(); v != 9 || !ok {
+		t.Errorf("PopBack() = %d, %t, want 9, true", v, ok)
+	}
+	d.Set(0, 100)
+	if v := d.At(0); v != 100 {
+		t.Errorf("At(0) after Set(0, 100) = %d, want 100", v)
+	}
+
+	for _, i := range []int{-1, d.Len()} {
+		if !panics(func() { d.At(i) }) {
+			t.Errorf("At(%d): got no panic, want panic", i)
+		}
+	}
+
+	c := d.Cap()
+	d.Clear()
+	if d.Len() != 0 || d.Cap() != c {
+		t.Errorf("after Clear(): Len() = %d, Cap() = %d, want 0, %d", d.Len(), d.Cap(), c)
+	}
+}
+
+func TestBoundedDeque(t *testing.T) {
+	t.Parallel()
+
+	d := NewBoundedDeque[int](3)
+	for i := 1; i <= 5; i++ {
+		d.PushBack(i)
+	}
+	if got := d.Slice(); !Equal(got, []int{3, 4, 5}) || d.Cap() != 3 {
+		t.Errorf("PushBack overwrite: Slice() = %v, want [3 4 5]", got)
+	}
+
+	d.PushFront(2)
+	if got := d.Slice(); !Equal(got, []int{2, 3, 4}) {
+		t.Errorf("PushFront overwrite: Slice() = %v, want [2 3 4]", got)
+	}
+
+	d.PopBack()
+	d.PushBack(7)
+	d.PushBack(8)
+	if got := d.Slice(); !Equal(got, []int{3, 7, 8}) {
+		t.Errorf("Slice() = %v, want [3 7 8]", got)
+	}
+
+	if !panics(func() { NewBoundedDeque[int](0) }) {
+		t.Errorf("NewBoundedDeque(0): got no panic, want panic")
+	}
+}
+
+func TestDeque_Random(t *testing.T) {
+	t.Parallel()
+
+	d := NewDeque([]int{1, 2, 3})
+	model := []int{1, 2, 3}
+	for i := 0; i < 1000; i++ {
+		switch rand.Intn(4) {
+		case 0:
+			d.PushBack(i)
+			model = append(model, i)
+		case 1:
+			d.PushFront(i)
+			model = Insert(model, 0, i)
+		case 2:
+			v, ok := d.PopBack()
+			if ok != (len(model) > 0) || ok && v != model[len(model)-1] {
+				t.Fatalf("PopBack() = %d, %t, want the back of %v", v, ok, model)
+			}
+			if ok {
+				model = model[:len(model)-1]
+			}
+		case 3:
+			v, ok := d.PopFront()
+			if ok != (len(model) > 0) || ok && v != model[0] {
+				t.Fatalf("PopFront() = %d, %t, want the front of %v", v, ok, model)
+			}
+			if ok {
+				model = model[1:]
+			}
+		}
+
+		if got := d.Slice(); !Equal(got, model) {
+			t.Fatalf("Slice() = %v, want %v", got, model)
+		}
+	}
+}
+
+// Tests for convenience wrappers.
+
+func TestSlice_Deque(t *testing.T) {
+	t.Parallel()
+
+	s := NewSlice([]int{1, 2, 3})
+	d := s.Deque()
+	d.PushFront(0)
+	if got := d.Slice(); !Equal(got, []int{0, 1, 2, 3}) {
+		t.Errorf("Deque().Slice() = %v, want [0 1 2 3]", got)
+	}
+	if !Equal(*s, []int{1, 2, 3}) {
+		t.Errorf("Deque() shares the receiver: %v", *s)
+	}
+}
+
+func BenchmarkDeque(b *testing.B) {
+	b.Run("PushFront", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var d Deque[int]
+			for j := 0; j < 1000; j++ {
+				d.PushFront(j)
+			}
+		}
+	})
+
+	b.Run("Insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var s []int
+			for j := 0; j < 1000; j++ {
+				s = Insert(s, 0, j)
+			}
+		}
+	})
+}
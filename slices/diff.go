@@ -0,0 +1,254 @@
+package slices
+
+import "strconv"
+
+// EditKind is the kind of an Edit.
+type EditKind int
+
+const (
+	// EditKeep keeps an element of the old slice that is equal to an element of the new slice.
+	EditKeep EditKind = iota
+	// EditDelete deletes an element of the old slice.
+	EditDelete
+	// EditInsert inserts an element of the new slice.
+	EditInsert
+)
+
+func (k EditKind) String() string {
+	switch k {
+	case EditKeep:
+		return "keep"
+	case EditDelete:
+		return "delete"
+	case EditInsert:
+		return "insert"
+	default:
+		return "EditKind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// Edit is an operation of an edit script transforming an old slice into a new slice.
+// OldIndex and NewIndex are the positions in the old and the new slice at which
+// the operation applies: EditKeep keeps old[OldIndex], which equals new[NewIndex];
+// EditDelete deletes old[OldIndex]; EditInsert inserts new[NewIndex] before old[OldIndex].
+type Edit struct {
+	Kind     EditKind
+	OldIndex int
+	NewIndex int
+}
+
+func (e Edit) String() string {
+	return e.Kind.String() + " " + strconv.Itoa(e.OldIndex) + " " + strconv.Itoa(e.NewIndex)
+}
+
+// Diff returns a minimal edit script transforming the slice a into the slice b,
+// that is a script with the fewest deletions and insertions.
+// The edits are ordered by increasing OldIndex and NewIndex,
+// and every element of a and of b is covered by exactly one edit.
+// Diff uses the Myers algorithm, which runs in O((len(a)+len(b)) * D)
+// time and O(D * D) additional space, where D is the number of deletions
+// and insertions, so it is fast when a and b are similar.
+func Diff[E comparable](a, b []E) []Edit {
+	return DiffFunc(a, b, func(x, y E) bool { return x == y })
+}
+
+// DiffFunc is like Diff but uses an equality function on each pair of elements.
+func DiffFunc[E1, E2 any](a []E1, b []E2, eq func(E1, E2) bool) []Edit {
+	// Trim the common prefix and suffix, which are kept as is.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && eq(a[prefix], b[prefix]) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && eq(a[len(a)-1-suffix], b[len(b)-1-suffix]) {
+		suffix++
+	}
+
+	script := make([]Edit, 0, len(a)+len(b)-prefix-suffix)
+	for i := 0; i < prefix; i++ {
+		script = append(script, Edit{EditKeep, i, i})
+	}
+	script = myers(script, a[prefix:len(a)-suffix], b[prefix:len(b)-suffix], prefix, eq)
+	for i := suffix; i > 0; i-- {
+		script = append(script, Edit{EditKeep, len(a) - i, len(b) - i})
+	}
+	return script
+}
+
+// myers appends to script the edits transforming a into b, with the indices
+// shifted by off, and returns the extended script.
+func myers[E1, E2 any](script []Edit, a []E1, b []E2, off int, eq func(E1, E2) bool) []Edit {
+	n, m := len(a), len(b)
+
+	// v[max+k] is the furthest x reached on diagonal k = x-y.
+	// trace[d] holds v[max-d:max+d+1] after d edits.
+	max := n + m
+	v := make([]int, 2*max+2)
+	var trace [][]int
+	for d, done := 0, false; !done; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[max+k-1] < v[max+k+1]) {
+				x = v[max+k+1] // insertion
+			} else {
+				x = v[max+k-1] + 1 // deletion
+			}
+			y := x - k
+			for x < n && y < m && eq(a[x], b[y]) {
+				x++
+				y++
+			}
+			v[max+k] = x
+
+			if x >= n && y >= m {
+				done = true
+				break
+			}
+		}
+		trace = append(trace, Clone(v[max-d:max+d+1]))
+	}
+
+	// Walk the trace backwards from (n, m) to (0, 0).
+	start := len(script)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		k := x - y
+
+		prevX, prevY := 0, 0
+		insert := false
+		if d > 0 {
+			prev := trace[d-1] // prev[d-1+k] is v[max+k]
+			prevK := k - 1
+			if k == -d || (k != d && prev[d-1+k-1] < prev[d-1+k+1]) {
+				prevK = k + 1
+				insert = true
+			}
+			prevX = prev[d-1+prevK]
+			prevY = prevX - prevK
+		}
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			script = append(script, Edit{EditKeep, off + x, off + y})
+		}
+		if d > 0 {
+			if insert {
+				y--
+				script = append(script, Edit{EditInsert, off + x, off + y})
+			} else {
+				x--
+				script = append(script, Edit{EditDelete, off + x, off + y})
+			}
+		}
+	}
+	Reverse(script[start:])
+	return script
+}
+
+// LongestCommonSubsequence returns a new slice of a longest sequence of elements
+// appearing in both a and b in the same relative order, not necessarily contiguously.
+// The elements are taken from a. It returns nil if a is nil.
+func LongestCommonSubsequence[S ~[]E, E comparable](a, b S) S {
+	return LongestCommonSubsequenceFunc(a, b, func(x, y E) bool { return x == y })
+}
+
+// LongestCommonSubsequenceFunc is like LongestCommonSubsequence but uses an equality function
+// on each pair of elements.
+func LongestCommonSubsequenceFunc[S ~[]E1, E1, E2 any](a S, b []E2, eq func(E1, E2) bool) S {
+	// Preserve nil in case it matters.
+	if a == nil {
+		return nil
+	}
+
+	r := S{}
+	for _, e := range DiffFunc(a, b, eq) {
+		if e.Kind == EditKeep {
+			r = append(r, a[e.OldIndex])
+		}
+	}
+	return r
+}
+
+// ApplyEdits returns a new slice obtained by applying the edit script to the slice a,
+// taking the inserted elements from the slice b. For a script returned by Diff(a, b),
+// the result equals b, except that kept elements are taken from a.
+// ApplyEdits panics if the script does not cover a and b in order,
+// as described by Diff.
+func ApplyEdits[S ~[]E, E any](a, b S, script []Edit) S {
+	r := make(S, 0, len(b))
+	i, j := 0, 0
+	for _, e := range script {
+		switch e.Kind {
+		case EditKeep:
+			if e.OldIndex != i || e.NewIndex != j {
+				panic("slices: edit script out of order")
+			}
+			r = append(r, a[i])
+			i++
+			j++
+		case EditDelete:
+			if e.OldIndex != i {
+				panic("slices: edit script out of order")
+			}
+			i++
+		case EditInsert:
+			if e.NewIndex != j {
+				panic("slices: edit script out of order")
+			}
+			r = append(r, b[j])
+			j++
+		default:
+			panic("slices: invalid edit kind")
+		}
+	}
+	if i != len(a) || j != len(b) {
+		panic("slices: edit script does not cover the slices")
+	}
+	return r
+}
+
+// Convenience wrappers for common cases.
+
+// DiffFunc returns the result of applying DiffFunc to the receiver, b and eq.
+func (s Slice[E]) DiffFunc(b []E, eq func(E, E) bool) []Edit {
+	return DiffFunc(s, b, eq)
+}
+
+// LongestCommonSubsequenceFunc returns the result of applying LongestCommonSubsequenceFunc
+// to the receiver, b and eq.
+func (s Slice[E]) LongestCommonSubsequenceFunc(b []E, eq func(E, E) bool) Slice[E] {
+	return LongestCommonSubsequenceFunc(s, b, eq)
+}
+
+// ApplyEdits returns the result of applying ApplyEdits to the receiver, b and script.
+func (s Slice[E]) ApplyEdits(b []E, script []Edit) Slice[E] {
+	return ApplyEdits(s, b, script)
+}
+
+// Diff returns the result of applying Diff to the receiver and b.
+func (s ComparableSlice[E]) Diff(b []E) []Edit {
+	return Diff(s, b)
+}
+
+// DiffFunc returns the result of applying DiffFunc to the receiver, b and eq.
+func (s ComparableSlice[E]) DiffFunc(b []E, eq func(E, E) bool) []Edit {
+	return DiffFunc(s, b, eq)
+}
+
+// LongestCommonSubsequence returns the result of applying LongestCommonSubsequence
+// to the receiver and b.
+func (s ComparableSlice[E]) LongestCommonSubsequence(b []E) ComparableSlice[E] {
+	return LongestCommonSubsequence(s, b)
+}
+
+// LongestCommonSubsequenceFunc returns the result of applying LongestCommonSubsequenceFunc
+// to the receiver, b and eq.
+func (s ComparableSlice[E]) LongestCommonSubsequenceFunc(b []E, eq func(E, E) bool) ComparableSlice[E] {
+	return LongestCommonSubsequenceFunc(s, b, eq)
+}
+
+// ApplyEdits returns the result of applying ApplyEdits to the receiver, b and script.
+func (s ComparableSlice[E]) ApplyEdits(b []E, script []Edit) ComparableSlice[E] {
+	return ApplyEdits(s, b, script)
+}
@@ -0,0 +1,191 @@
+package slices_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+var diffTests = []struct {
+	a, b string
+	want string // one character per edit: '=' keep, '-' delete, '+' insert
+}{
+	{"", "", ""},
+	{"abc", "abc", "==="},
+	{"", "abc", "+++"},
+	{"abc", "", "---"},
+	{"abc", "axc", "=-+="},
+	{"abcabba", "cbabac", "--=-==-=+"},
+	{"abcd", "acd", "=-=="},
+	{"acd", "abcd", "=+=="},
+	{"ab", "ba", "-=+"},
+}
+
+func script(edits []Edit) string {
+	var b strings.Builder
+	for _, e := range edits {
+		b.WriteByte("=-+"[e.Kind])
+	}
+	return b.String()
+}
+
+// lcsLen returns the length of the longest common subsequence of a and b,
+// using dynamic programming.
+func lcsLen(a, b []int) int {
+	dp := make([]int, len(b)+1)
+	for i := range a {
+		prev := 0
+		for j := range b {
+			cur := dp[j+1]
+			if a[i] == b[j] {
+				dp[j+1] = prev + 1
+			} else if dp[j] > dp[j+1] {
+				dp[j+1] = dp[j]
+			}
+			prev = cur
+		}
+	}
+	return dp[len(b)]
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range diffTests {
+		a, b := []byte(tc.a), []byte(tc.b)
+		edits := Diff(a, b)
+		if got := script(edits); len(got) != len(tc.want) || strings.Count(got, "=") != strings.Count(tc.want, "=") {
+			t.Errorf("Diff(%q, %q) = %q, want an edit script like %q", tc.a, tc.b, got, tc.want)
+		}
+		if got := ApplyEdits(a, b, edits); string(got) != tc.b {
+			t.Errorf("ApplyEdits(%q, %q, %v) = %q, want %[2]q", tc.a, tc.b, edits, got)
+		}
+	}
+
+	// The common prefix and suffix are kept in place.
+	if got := script(Diff([]byte("abcabba"), []byte("abcbabba"))); got != "===+====" {
+		t.Errorf("Diff(abcabba, abcbabba) = %q, want \"===+====\"", got)
+	}
+
+	for i := 0; i < 200; i++ {
+		a := make([]int, rand.Intn(30))
+		for i := range a {
+			a[i] = rand.Intn(4)
+		}
+		b := make([]int, rand.Intn(30))
+		for i := range b {
+			b[i] = rand.Intn(4)
+		}
+
+		edits := Diff(a, b)
+		l := lcsLen(a, b)
+		keeps, deletes, inserts := 0, 0, 0
+		for _, e := range edits {
+			switch e.Kind {
+			case EditKeep:
+				keeps++
+				if a[e.OldIndex] != b[e.NewIndex] {
+					t.Errorf("Diff(%v, %v): %v keeps unequal elements", a, b, e)
+				}
+			case EditDelete:
+				deletes++
+			case EditInsert:
+				inserts++
+			}
+		}
+		if keeps != l || deletes != len(a)-l || inserts != len(b)-l {
+			t.Errorf("Diff(%v, %v) = %v, not minimal: LCS length %d", a, b, edits, l)
+		}
+		if got := ApplyEdits(a, b, edits); !Equal(got, b) {
+			t.Errorf("ApplyEdits(%v, %v, %v) = %v, want %[2]v", a, b, edits, got)
+		}
+		if got := LongestCommonSubsequence(a, b); len(got) != l {
+			t.Errorf("LongestCommonSubsequence(%v, %v) = %v, want length %d", a, b, got, l)
+		}
+	}
+}
+
+func TestDiffFunc(t *testing.T) {
+	t.Parallel()
+
+	type resource struct {
+		name    string
+		version int
+	}
+	actual := []resource{{"a", 1}, {"b", 1}, {"c", 1}}
+	desired := []string{"a", "c", "d"}
+
+	edits := DiffFunc(actual, desired, func(r resource, name string) bool { return r.name == name })
+	want := []Edit{{EditKeep, 0, 0}, {EditDelete, 1, 1}, {EditKeep, 2, 1}, {EditInsert, 3, 2}}
+	if !Equal(edits, want) {
+		t.Errorf("DiffFunc(%v, %v) = %v, want %v", actual, desired, edits, want)
+	}
+
+	lcs := LongestCommonSubsequenceFunc(actual, desired, func(r resource, name string) bool { return r.name == name })
+	if want := []resource{{"a", 1}, {"c", 1}}; !Equal(lcs, want) {
+		t.Errorf("LongestCommonSubsequenceFunc(%v, %v) = %v, want %v", actual, desired, lcs, want)
+	}
+}
+
+func TestLongestCommonSubsequence(t *testing.T) {
+	t.Parallel()
+
+	if got := LongestCommonSubsequence([]int(nil), []int{1}); got != nil {
+		t.Errorf("LongestCommonSubsequence(nil, [1]) = %#v, want nil", got)
+	}
+	if got := LongestCommonSubsequence([]int{1}, nil); got == nil || len(got) != 0 {
+		t.Errorf("LongestCommonSubsequence([1], nil) = %#v, want []int{}", got)
+	}
+	if got := string(LongestCommonSubsequence([]byte("ABCBDAB"), []byte("BDCABA"))); len(got) != 4 {
+		t.Errorf("LongestCommonSubsequence(ABCBDAB, BDCABA) = %q, want length 4", got)
+	}
+}
+
+func TestApplyEditsPanics(t *testing.T) {
+	t.Parallel()
+
+	a, b := []int{1, 2}, []int{1, 3}
+	for _, edits := range [][]Edit{
+		{{EditKeep, 0, 0}},
+		{{EditKeep, 0, 0}, {EditKeep, 1, 1}, {EditInsert, 2, 1}},
+		{{EditDelete, 1, 0}},
+		{{EditKind(3), 0, 0}},
+	} {
+		if !panics(func() { ApplyEdits(a, b, edits) }) {
+			t.Errorf("ApplyEdits(%v, %v, %v): got no panic, want panic", a, b, edits)
+		}
+	}
+}
+
+// Tests for convenience wrappers.
+
+func TestComparableSlice_Diff(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range diffTests {
+		a := NewComparableSlice([]byte(tc.a))
+		edits := a.Diff([]byte(tc.b))
+		if got := a.ApplyEdits([]byte(tc.b), edits); string(got) != tc.b {
+			t.Errorf("%q.ApplyEdits(%q, %v) = %q, want %[2]q", tc.a, tc.b, edits, got)
+		}
+		if got, want := len(a.LongestCommonSubsequence([]byte(tc.b))), strings.Count(tc.want, "="); got != want {
+			t.Errorf("%q.LongestCommonSubsequence(%q) has length %d, want %d", tc.a, tc.b, got, want)
+		}
+	}
+}
+
+func BenchmarkDiff(b *testing.B) {
+	x := sliceGenerator(10_000)
+	y := Clone(x)
+	for i := 0; i < 20; i++ {
+		j := rand.Intn(len(y))
+		y[j] = -y[j]
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Diff(x, y)
+	}
+}
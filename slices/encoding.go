@@ -0,0 +1,213 @@
+package slices
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// EncodingOption configures MarshalJSON.
+type EncodingOption func(*encodingOptions)
+
+type encodingOptions struct {
+	nilAsEmpty bool
+}
+
+// WithNilAsEmpty encodes a nil slice as an empty JSON array [] instead of null.
+// The NilAsEmpty type does the same for json.Marshal.
+func WithNilAsEmpty() EncodingOption {
+	return func(o *encodingOptions) {
+		o.nilAsEmpty = true
+	}
+}
+
+// MarshalJSON returns the JSON encoding of the slice s: a JSON array
+// of the elements of s encoded by json.Marshal, or null if s is nil,
+// so that nil and empty slices are distinguished.
+// WithNilAsEmpty can be used to encode a nil slice as [].
+func MarshalJSON[S ~[]E, E any](s S, opts ...EncodingOption) ([]byte, error) {
+	var o encodingOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if s == nil {
+		if o.nilAsEmpty {
+			return []byte("[]"), nil
+		}
+		return []byte("null"), nil
+	}
+
+	// Convert to []E so that the MarshalJSON methods are not called again.
+	return json.Marshal([]E(s))
+}
+
+// UnmarshalJSON parses the JSON-encoded data and stores the result in the slice
+// pointed to by s, reversing MarshalJSON: null sets *s to nil, and an empty
+// array sets *s to an empty non-nil slice.
+// Unlike json.Unmarshal, which leaves a slice unchanged for null,
+// it always replaces *s.
+func UnmarshalJSON[S ~[]E, E any](data []byte, s *S) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		*s = nil
+		return nil
+	}
+
+	var r []E
+	if err := json.Unmarshal(data, &r); err != nil {
+		return err
+	}
+
+	*s = r
+	return nil
+}
+
+// NilAsEmpty is a slice whose MarshalJSON method encodes it as an empty JSON array []
+// when it is nil, like MarshalJSON with WithNilAsEmpty, instead of null.
+// It can be used for struct fields passed to json.Marshal, whose consumers
+// expect an array:
+//
+//	type Response struct {
+//		Items slices.NilAsEmpty[Item] `json:"items"`
+//	}
+//
+// Its UnmarshalJSON method still decodes null as a nil slice.
+type NilAsEmpty[E any] []E
+
+// MarshalJSON implements the json.Marshaler interface, see the MarshalJSON function.
+func (s NilAsEmpty[E]) MarshalJSON() ([]byte, error) {
+	return MarshalJSON(s, WithNilAsEmpty())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, see the UnmarshalJSON function.
+func (s *NilAsEmpty[E]) UnmarshalJSON(data []byte) error {
+	return UnmarshalJSON(data, s)
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+// errBinaryLength is returned by UnmarshalBinary when the length of the data
+// does not match its length prefix.
+var errBinaryLength = errors.New("slices: invalid binary data length")
+
+// MarshalBinary returns the binary encoding of the slice s, whose element type
+// must have a fixed size as defined by encoding/binary, such as int32, float64,
+// or arrays and structs of them (but not int or uint).
+// The encoding is the varint-encoded len(s)+1, or 0 if s is nil,
+// followed by the elements of s in little-endian byte order.
+func MarshalBinary[S ~[]E, E any](s S) ([]byte, error) {
+	size, err := binarySize[E]()
+	if err != nil {
+		return nil, err
+	}
+
+	var prefix uint64
+	if s != nil {
+		prefix = uint64(len(s)) + 1
+	}
+
+	b := make([]byte, 0, binary.MaxVarintLen64+len(s)*size)
+	buf := bytes.NewBuffer(binary.AppendUvarint(b, prefix))
+	if len(s) > 0 {
+		if err := binary.Write(buf, binary.LittleEndian, []E(s)); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the binary encoding produced by MarshalBinary
+// and stores the result in the slice pointed to by s.
+func UnmarshalBinary[S ~[]E, E any](data []byte, s *S) error {
+	size, err := binarySize[E]()
+	if err != nil {
+		return err
+	}
+
+	prefix, k := binary.Uvarint(data)
+	if k <= 0 {
+		return errBinaryLength
+	}
+	data = data[k:]
+
+	if prefix == 0 {
+		if len(data) != 0 {
+			return errBinaryLength
+		}
+		*s = nil
+		return nil
+	}
+
+	n := prefix - 1
+	switch {
+	case size == 0 && (len(data) != 0 || n > uint64(maxInt)),
+		size > 0 && (len(data)%size != 0 || uint64(len(data)/size) != n):
+		return errBinaryLength
+	}
+
+	r := make([]E, n)
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, r); err != nil {
+		return err
+	}
+	*s = r
+	return nil
+}
+
+// binarySize returns the encoded size of a value of type E,
+// or an error if E does not have a fixed size.
+func binarySize[E any]() (int, error) {
+	var zero E
+	size := binary.Size(zero)
+	if size < 0 {
+		return 0, fmt.Errorf("slices: element type %T does not have a fixed size", zero)
+	}
+	return size, nil
+}
+
+// Convenience wrappers for common cases.
+
+// MarshalJSON implements the json.Marshaler interface, see the MarshalJSON function.
+// A nil slice is encoded as null, as encoding/json does for slices;
+// convert it to NilAsEmpty to encode it as [].
+func (s Slice[E]) MarshalJSON() ([]byte, error) {
+	return MarshalJSON(s)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, see the UnmarshalJSON function.
+func (s *Slice[E]) UnmarshalJSON(data []byte) error {
+	return UnmarshalJSON(data, s)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, see the MarshalBinary function.
+func (s Slice[E]) MarshalBinary() ([]byte, error) {
+	return MarshalBinary(s)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, see the UnmarshalBinary function.
+func (s *Slice[E]) UnmarshalBinary(data []byte) error {
+	return UnmarshalBinary(data, s)
+}
+
+// MarshalJSON implements the json.Marshaler interface, see the MarshalJSON function.
+// A nil slice is encoded as null, as encoding/json does for slices;
+// convert it to NilAsEmpty to encode it as [].
+func (s ComparableSlice[E]) MarshalJSON() ([]byte, error) {
+	return MarshalJSON(s)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, see the UnmarshalJSON function.
+func (s *ComparableSlice[E]) UnmarshalJSON(data []byte) error {
+	return UnmarshalJSON(data, s)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, see the MarshalBinary function.
+func (s ComparableSlice[E]) MarshalBinary() ([]byte, error) {
+	return MarshalBinary(s)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, see the UnmarshalBinary function.
+func (s *ComparableSlice[E]) UnmarshalBinary(data []byte) error {
+	return UnmarshalBinary(data, s)
+}
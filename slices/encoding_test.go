@@ -0,0 +1,190 @@
+package slices_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+var (
+	_ json.Marshaler             = Slice[int]{}
+	_ json.Unmarshaler           = (*Slice[int])(nil)
+	_ encoding.BinaryMarshaler   = ComparableSlice[int]{}
+	_ encoding.BinaryUnmarshaler = (*ComparableSlice[int])(nil)
+)
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		s          []int
+		want       string
+		nilAsEmpty string
+	}{
+		{nil, "null", "[]"},
+		{[]int{}, "[]", "[]"},
+		{[]int{1, 2, 3}, "[1,2,3]", "[1,2,3]"},
+	}
+	for _, tc := range tests {
+		got, err := MarshalJSON(tc.s)
+		if err != nil || string(got) != tc.want {
+			t.Errorf("MarshalJSON(%#v) = %s, %v, want %s", tc.s, got, err, tc.want)
+		}
+		got, err = MarshalJSON(tc.s, WithNilAsEmpty())
+		if err != nil || string(got) != tc.nilAsEmpty {
+			t.Errorf("MarshalJSON(%#v, WithNilAsEmpty()) = %s, %v, want %s", tc.s, got, err, tc.nilAsEmpty)
+		}
+
+		var s []int
+		if tc.s == nil {
+			s = []int{9}
+		}
+		if err := UnmarshalJSON([]byte(tc.want), &s); err != nil {
+			t.Fatalf("UnmarshalJSON(%s): %v", tc.want, err)
+		}
+		if !Equal(s, tc.s) || (s == nil) != (tc.s == nil) {
+			t.Errorf("UnmarshalJSON(%s) = %#v, want %#v", tc.want, s, tc.s)
+		}
+	}
+
+	var s []int
+	if err := UnmarshalJSON([]byte(`{"a":1}`), &s); err == nil {
+		t.Errorf("UnmarshalJSON(object): got no error")
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	type point struct{ X, Y float32 }
+	tests := [][]point{nil, {}, {{1, 2}}, {{1, 2}, {3.5, -4}}}
+	for _, want := range tests {
+		data, err := MarshalBinary(want)
+		if err != nil {
+			t.Fatalf("MarshalBinary(%v): %v", want, err)
+		}
+		if n := 1 + 8*len(want); len(data) != n {
+			t.Errorf("MarshalBinary(%v): %d bytes, want %d", want, len(data), n)
+		}
+
+		var got []point
+		if err := UnmarshalBinary(data, &got); err != nil {
+			t.Fatalf("UnmarshalBinary(%v): %v", data, err)
+		}
+		if !Equal(got, want) || (got == nil) != (want == nil) {
+			t.Errorf("UnmarshalBinary(MarshalBinary(%#v)) = %#v", want, got)
+		}
+	}
+
+	data, _ := MarshalBinary([]uint16{0x0102, 0x0304})
+	if want := []byte{3, 0x02, 0x01, 0x04, 0x03}; !Equal(data, want) {
+		t.Errorf("MarshalBinary([0x0102 0x0304]) = %x, want %x", data, want)
+	}
+
+	if _, err := MarshalBinary([]int{1}); err == nil {
+		t.Errorf("MarshalBinary([]int): got no error")
+	}
+	if _, err := MarshalBinary([]string{"a"}); err == nil {
+		t.Errorf("MarshalBinary([]string): got no error")
+	}
+
+	for _, data := range [][]byte{nil, {0, 1}, {3, 1, 2, 3}, {2, 1, 2, 3}, {0x80}} {
+		var s []uint16
+		if err := UnmarshalBinary(data, &s); err == nil {
+			t.Errorf("UnmarshalBinary(%x): got no error", data)
+		}
+	}
+}
+
+func TestNilAsEmpty(t *testing.T) {
+	t.Parallel()
+
+	type doc struct {
+		A NilAsEmpty[int]
+		B NilAsEmpty[int] `json:",omitempty"`
+	}
+	tests := []struct {
+		d    doc
+		want string
+	}{
+		{doc{}, `{"A":[]}`},
+		{doc{NilAsEmpty[int]{}, NilAsEmpty[int]{}}, `{"A":[]}`},
+		{doc{NilAsEmpty[int]{1}, NilAsEmpty[int]{2, 3}}, `{"A":[1],"B":[2,3]}`},
+	}
+	for _, tc := range tests {
+		data, err := json.Marshal(tc.d)
+		if err != nil || string(data) != tc.want {
+			t.Errorf("json.Marshal(%#v) = %s, %v, want %s", tc.d, data, err, tc.want)
+		}
+	}
+
+	got := doc{A: NilAsEmpty[int]{1}}
+	if err := json.Unmarshal([]byte(`{"A":null,"B":[]}`), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if got.A != nil || got.B == nil || len(got.B) != 0 {
+		t.Errorf("json.Unmarshal({A:null, B:[]}) = %#v, want nil A and empty B", got)
+	}
+
+	// Converting a Slice is enough to change its encoding.
+	var s Slice[string]
+	if data, err := json.Marshal(NilAsEmpty[string](s)); err != nil || string(data) != "[]" {
+		t.Errorf("json.Marshal(NilAsEmpty(nil Slice)) = %s, %v, want []", data, err)
+	}
+}
+
+// Tests for convenience wrappers.
+
+func TestSlice_JSON(t *testing.T) {
+	t.Parallel()
+
+	type doc struct {
+		A Slice[string]
+		B ComparableSlice[int]
+	}
+	for _, want := range []doc{{}, {Slice[string]{}, ComparableSlice[int]{}}, {Slice[string]{"x"}, ComparableSlice[int]{1, 2}}} {
+		data, err := json.Marshal(want)
+		if err != nil {
+			t.Fatalf("json.Marshal(%#v): %v", want, err)
+		}
+
+		got := doc{Slice[string]{"old"}, ComparableSlice[int]{7}}
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("json.Unmarshal(%s): %v", data, err)
+		}
+		if !Equal(got.A, want.A) || (got.A == nil) != (want.A == nil) ||
+			!Equal(got.B, want.B) || (got.B == nil) != (want.B == nil) {
+			t.Errorf("json round trip of %#v = %#v", want, got)
+		}
+	}
+}
+
+func TestSlice_Binary(t *testing.T) {
+	t.Parallel()
+
+	for _, want := range []Slice[float64]{nil, {}, {1.5, -2}} {
+		data, err := want.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%v.MarshalBinary(): %v", want, err)
+		}
+		got := Slice[float64]{9}
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary(%x): %v", data, err)
+		}
+		if !Equal(got, want) || (got == nil) != (want == nil) {
+			t.Errorf("binary round trip of %#v = %#v", want, got)
+		}
+	}
+
+	c := ComparableSlice[int8]{-1, 2}
+	data, err := c.MarshalBinary()
+	if err != nil {
+		t.Fatalf("%v.MarshalBinary(): %v", c, err)
+	}
+	var got ComparableSlice[int8]
+	if err := got.UnmarshalBinary(data); err != nil || !Equal(got, c) {
+		t.Errorf("binary round trip of %v = %v, %v", c, got, err)
+	}
+}
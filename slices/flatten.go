@@ -0,0 +1,108 @@
+package slices
+
+// FlatMap returns a new slice of the concatenation of f(i, v) for the elements of s.
+// It returns nil if s is nil.
+func FlatMap[S ~[]E1, E1, E2 any](s S, f func(int, E1) []E2) []E2 {
+	// Preserve nil in case it matters.
+	if s == nil {
+		return nil
+	}
+
+	r := make([]E2, 0, len(s))
+	for i, v := range s {
+		r = append(r, f(i, v)...)
+	}
+	return r
+}
+
+// Flatten returns a new slice of the concatenation of the slices in ss,
+// allocated once. It returns nil if ss is nil.
+func Flatten[S ~[]E, E any](ss []S) S {
+	// Preserve nil in case it matters.
+	if ss == nil {
+		return nil
+	}
+
+	return concat(ss)
+}
+
+// Concat returns a new slice of the concatenation of the slices ss,
+// allocated once. It returns nil if all the slices are nil,
+// or if no slice is passed.
+func Concat[S ~[]E, E any](ss ...S) S {
+	for _, s := range ss {
+		if s != nil {
+			return concat(ss)
+		}
+	}
+	return nil
+}
+
+// concat returns a new non-nil slice of the concatenation of the slices in ss.
+func concat[S ~[]E, E any](ss []S) S {
+	n := 0
+	for _, s := range ss {
+		n += len(s)
+	}
+
+	r := make(S, 0, n)
+	for _, s := range ss {
+		r = append(r, s...)
+	}
+	return r
+}
+
+// Pair is a pair of values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip returns a new slice of the pairs of elements of a and b at the same index.
+// The result has the length of the shorter slice; the remaining elements
+// of the longer one are ignored.
+// It returns nil if a or b is nil.
+func Zip[S1 ~[]A, S2 ~[]B, A, B any](a S1, b S2) []Pair[A, B] {
+	// Preserve nil in case it matters.
+	if a == nil || b == nil {
+		return nil
+	}
+
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+
+	r := make([]Pair[A, B], n)
+	for i := range r {
+		r[i] = Pair[A, B]{a[i], b[i]}
+	}
+	return r
+}
+
+// Unzip returns two new slices of the first and the second values of the pairs in s,
+// reversing Zip. It returns nil slices if s is nil.
+func Unzip[S ~[]Pair[A, B], A, B any](s S) ([]A, []B) {
+	// Preserve nil in case it matters.
+	if s == nil {
+		return nil, nil
+	}
+
+	a, b := make([]A, len(s)), make([]B, len(s))
+	for i, p := range s {
+		a[i], b[i] = p.First, p.Second
+	}
+	return a, b
+}
+
+// Convenience wrappers for common cases.
+
+// Concat returns the result of applying Concat to the receiver and ss.
+func (s Slice[E]) Concat(ss ...[]E) Slice[E] {
+	return Concat(append([][]E{s}, ss...)...)
+}
+
+// Concat returns the result of applying Concat to the receiver and ss.
+func (s ComparableSlice[E]) Concat(ss ...[]E) ComparableSlice[E] {
+	return Concat(append([][]E{s}, ss...)...)
+}
@@ -0,0 +1,119 @@
+package slices_test
+
+import (
+	"strconv"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+func TestFlatMap(t *testing.T) {
+	t.Parallel()
+
+	if got := FlatMap([]int(nil), func(int, int) []int { return []int{1} }); got != nil {
+		t.Errorf("FlatMap(nil) = %v, want nil", got)
+	}
+	if got := FlatMap([]int{}, func(int, int) []int { return nil }); got == nil {
+		t.Errorf("FlatMap([]) = nil, want []")
+	}
+
+	s := []int{1, 2, 3}
+	got := FlatMap(s, func(i, v int) []string {
+		return Repeat(strconv.Itoa(v), i)
+	})
+	if want := []string{"2", "3", "3"}; !Equal(got, want) {
+		t.Errorf("FlatMap(%v) = %v, want %v", s, got, want)
+	}
+}
+
+func TestConcat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ss   [][]int
+		want []int
+	}{
+		{nil, nil},
+		{[][]int{nil, nil}, nil},
+		{[][]int{nil, {}}, []int{}},
+		{[][]int{{1, 2}, nil, {3}, {}, {4, 5}}, []int{1, 2, 3, 4, 5}},
+	}
+	for _, tc := range tests {
+		got := Concat(tc.ss...)
+		if !Equal(got, tc.want) || (got == nil) != (tc.want == nil) {
+			t.Errorf("Concat(%v) = %#v, want %#v", tc.ss, got, tc.want)
+		}
+		if cap(got) != len(got) {
+			t.Errorf("Concat(%v): cap = %d, want %d", tc.ss, cap(got), len(got))
+		}
+	}
+
+	s := []int{1, 2, 3}
+	got := Concat(s[:1], s[2:])
+	got[0] = 99
+	if s[0] != 1 {
+		t.Errorf("Concat modified its argument")
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ss   [][]int
+		want []int
+	}{
+		{nil, nil},
+		{[][]int{}, []int{}},
+		{[][]int{nil, nil}, []int{}},
+		{[][]int{{1, 2}, nil, {3}, {}, {4, 5}}, []int{1, 2, 3, 4, 5}},
+	}
+	for _, tc := range tests {
+		got := Flatten(tc.ss)
+		if !Equal(got, tc.want) || (got == nil) != (tc.want == nil) {
+			t.Errorf("Flatten(%v) = %#v, want %#v", tc.ss, got, tc.want)
+		}
+	}
+}
+
+func TestZip(t *testing.T) {
+	t.Parallel()
+
+	if got := Zip([]int(nil), []string{"a"}); got != nil {
+		t.Errorf("Zip(nil, [a]) = %v, want nil", got)
+	}
+	if got := Zip([]int{}, []string{}); got == nil {
+		t.Errorf("Zip([], []) = nil, want []")
+	}
+
+	a, b := []int{1, 2, 3}, []string{"a", "b"}
+	got := Zip(a, b)
+	want := []Pair[int, string]{{1, "a"}, {2, "b"}}
+	if !Equal(got, want) {
+		t.Errorf("Zip(%v, %v) = %v, want %v", a, b, got, want)
+	}
+
+	ga, gb := Unzip(got)
+	if !Equal(ga, a[:2]) || !Equal(gb, b) {
+		t.Errorf("Unzip(%v) = %v, %v, want %v, %v", got, ga, gb, a[:2], b)
+	}
+	if ga, gb := Unzip([]Pair[int, string](nil)); ga != nil || gb != nil {
+		t.Errorf("Unzip(nil) = %v, %v, want nil, nil", ga, gb)
+	}
+}
+
+// Tests for convenience wrappers.
+
+func TestSlice_Concat(t *testing.T) {
+	t.Parallel()
+
+	s := NewSlice([]int{1, 2})
+	if got, want := s.Concat([]int{3}, nil, []int{4}), []int{1, 2, 3, 4}; !Equal(got, want) {
+		t.Errorf("%v.Concat() = %v, want %v", s, got, want)
+	}
+
+	c := NewComparableSlice([]int(nil))
+	if got := c.Concat(nil); got != nil {
+		t.Errorf("%v.Concat(nil) = %v, want nil", c, got)
+	}
+}
@@ -1,9 +1,8 @@
 package slices
 
 import (
+	"context"
 	"math/rand"
-	"runtime"
-	"sync"
 
 	"golang.org/x/exp/constraints"
 )
@@ -24,53 +23,55 @@ func Filter[S ~[]E, E any](s S, f func(int, E) bool) S {
 }
 
 // PFilter returns a new slice of elements satisfies f(i, v).
-// f is call in a goroutine. Result may not keep the original order.
+// f is call in a goroutine. Result may not keep the original order,
+// use PFilterStable if the order matters.
+// If f panics, the remaining work is stopped and PFilter panics
+// with a *PanicError on the calling goroutine.
 func PFilter[S ~[]E, E any](s S, f func(int, E) bool) S {
 	if s == nil {
 		return nil
 	}
 
-	ngoroutines := runtime.NumCPU()
-	n := len(s)
-	step := n / ngoroutines
-	if step == 0 {
-		step = 1
-	}
+	o := newOptions(len(s), nil)
+	c := make(chan E, o.concurrency)
+	done := make(chan S, 1)
 
-	c := make(chan E, ngoroutines)
-
-	var wg sync.WaitGroup
-	for g := 0; g < ngoroutines; g++ {
-		start := g * step
-		if start >= n {
-			break
+	go func() {
+		r := make(S, 0, len(s))
+		for v := range c {
+			r = append(r, v)
 		}
+		done <- r
+	}()
 
-		end := start + step
-		if end > n {
-			end = n
-		}
+	func() {
+		// Close c even if forEachChunk panics, so the collecting goroutine exits.
+		defer close(c)
 
-		wg.Add(1)
-		go func(s S) {
-			for i, v := range s {
-				if f(start+i, v) {
-					c <- v
+		forEachChunk(context.Background(), len(s), o, func(ctx context.Context, start, end int) error {
+			for i := start; i < end; i++ {
+				if canceled(ctx) {
+					return nil
 				}
-			}
-			wg.Done()
-		}(s[start:end])
-	}
 
-	go func() {
-		wg.Wait()
-		close(c)
+				if f(i, s[i]) {
+					c <- s[i]
+				}
+			}
+			return nil
+		})
 	}()
 
-	r := make(S, 0, len(s))
-	for v := range c {
-		r = append(r, v)
-	}
+	return <-done
+}
+
+// PFilterStable is like PFilter but result keeps the original order.
+// The slice is split into chunks that are filtered concurrently,
+// and the kept elements of each chunk are concatenated in order.
+func PFilterStable[S ~[]E, E any](s S, f func(int, E) bool) S {
+	r, _ := PFilterCtx(context.Background(), s, func(_ context.Context, i int, v E) (bool, error) {
+		return f(i, v), nil
+	})
 	return r
 }
 
@@ -89,55 +90,24 @@ func Map[S ~[]E1, E1, E2 any](s S, f func(int, E1) E2) []E2 {
 
 // PMap manipulates a slice and transforms it to a slice of another type.
 // f is call in a goroutine. Result keep the same order.
+// If f panics, the remaining work is stopped and PMap panics
+// with a *PanicError on the calling goroutine.
 func PMap[S ~[]E1, E1, E2 any](s S, f func(int, E1) E2) []E2 {
 	if s == nil {
 		return nil
 	}
 
-	ngoroutines := runtime.NumCPU()
-	n := len(s)
-	step := n / ngoroutines
-	if step == 0 {
-		step = 1
-	}
-
-	type result struct {
-		i int
-		v E2
-	}
-
-	c := make(chan result, ngoroutines)
-
-	var wg sync.WaitGroup
-	for g := 0; g < ngoroutines; g++ {
-		start := g * step
-		if start >= n {
-			break
-		}
-
-		end := start + step
-		if end > n {
-			end = n
-		}
-
-		wg.Add(1)
-		go func(s S) {
-			for i, v := range s {
-				c <- result{start + i, f(start+i, v)}
+	r := make([]E2, len(s))
+	forEachChunk(context.Background(), len(s), newOptions(len(s), nil), func(ctx context.Context, start, end int) error {
+		for i := start; i < end; i++ {
+			if canceled(ctx) {
+				return nil
 			}
-			wg.Done()
-		}(s[start:end])
-	}
 
-	go func() {
-		wg.Wait()
-		close(c)
-	}()
-
-	r := make([]E2, len(s))
-	for v := range c {
-		r[v.i] = v.v
-	}
+			r[i] = f(i, s[i])
+		}
+		return nil
+	})
 	return r
 }
 
@@ -150,6 +120,49 @@ func Reduce[S ~[]E1, E1, E2 any](s S, f func(E2, int, E1) E2, init E2) E2 {
 	return acc
 }
 
+// PReduce is like Reduce but reduces chunks of the slice s concurrently.
+// Each chunk is reduced by f starting from a new value returned by identity,
+// and the partial results are then merged pairwise, in order, by combine.
+// combine must be associative, and identity must return an identity element
+// of combine, so that the result does not depend on how s is split into chunks;
+// f(acc, i, v) must equal combine(acc, f(identity(), i, v)).
+// Since identity is called once per chunk, f and combine may modify and return
+// their accumulator, such as a map. PReduce returns identity() if s is empty.
+// f is called in goroutines. The concurrency and the chunk size can be chosen through opts.
+// If f panics, the remaining work is stopped and PReduce panics
+// with a *PanicError on the calling goroutine.
+func PReduce[S ~[]E1, E1, E2 any](s S, f func(E2, int, E1) E2, combine func(E2, E2) E2, identity func() E2, opts ...Option) E2 {
+	o := newOptions(len(s), opts)
+	partials := make([]E2, (len(s)+o.chunkSize-1)/o.chunkSize)
+	forEachChunk(context.Background(), len(s), o, func(ctx context.Context, start, end int) error {
+		acc := identity()
+		for i := start; i < end; i++ {
+			if canceled(ctx) {
+				return nil
+			}
+
+			acc = f(acc, i, s[i])
+		}
+		partials[start/o.chunkSize] = acc
+		return nil
+	})
+
+	if len(partials) == 0 {
+		return identity()
+	}
+
+	// Merge adjacent partial results, halving their number at each round.
+	for n := len(partials); n > 1; n = (n + 1) / 2 {
+		for i := 0; i < n/2; i++ {
+			partials[i] = combine(partials[2*i], partials[2*i+1])
+		}
+		if n%2 == 1 {
+			partials[n/2] = partials[n-1]
+		}
+	}
+	return partials[0]
+}
+
 // ForEach applies function f to each element of the slice s in order.
 func ForEach[S ~[]E, E any](s S, f func(int, E)) {
 	for i, v := range s {
@@ -159,36 +172,19 @@ func ForEach[S ~[]E, E any](s S, f func(int, E)) {
 
 // PForEach applies function f to each element of the slice s in concurrency.
 // f is call in a goroutine.
+// If f panics, the remaining work is stopped and PForEach panics
+// with a *PanicError on the calling goroutine.
 func PForEach[S ~[]E, E any](s S, f func(int, E)) {
-	ngoroutines := runtime.NumCPU()
-	n := len(s)
-	step := n / ngoroutines
-	if step == 0 {
-		step = 1
-	}
-
-	var wg sync.WaitGroup
-	for g := 0; g < ngoroutines; g++ {
-		start := g * step
-		if start >= n {
-			break
-		}
-
-		end := start + step
-		if end > n {
-			end = n
-		}
-
-		wg.Add(1)
-		go func(s S) {
-			for i, v := range s {
-				f(start+i, v)
+	forEachChunk(context.Background(), len(s), newOptions(len(s), nil), func(ctx context.Context, start, end int) error {
+		for i := start; i < end; i++ {
+			if canceled(ctx) {
+				return nil
 			}
-			wg.Done()
-		}(s[start:end])
-	}
 
-	wg.Wait()
+			f(i, s[i])
+		}
+		return nil
+	})
 }
 
 // Shuffle returns a slice of shuffled elements of the slice s.
@@ -301,6 +297,96 @@ func Min[E constraints.Ordered](s ...E) E {
 	return min
 }
 
+// Chunk splits the slice s into consecutive chunks of size elements;
+// the last chunk has fewer elements if len(s) is not a multiple of size.
+// The chunks share the underlying array of s, but their capacity is clipped,
+// so appending to a chunk never overwrites the next one.
+// It returns nil if s is nil, and panics if size < 1.
+func Chunk[S ~[]E, E any](s S, size int) []S {
+	if size < 1 {
+		panic("slices: Chunk size cannot be less than 1")
+	}
+
+	// Preserve nil in case it matters.
+	if s == nil {
+		return nil
+	}
+
+	r := make([]S, 0, (len(s)+size-1)/size)
+	for i := 0; i < len(s); i += size {
+		end := i + size
+		if end > len(s) {
+			end = len(s)
+		}
+		r = append(r, s[i:end:end])
+	}
+	return r
+}
+
+// Window returns all the sliding windows of size consecutive elements of the slice s,
+// in order: s[0:size], s[1:size+1], and so on. There are no windows if len(s) < size.
+// The windows share the underlying array of s, but their capacity is clipped,
+// so appending to a window never overwrites the elements of s.
+// It returns nil if s is nil, and panics if size < 1.
+func Window[S ~[]E, E any](s S, size int) []S {
+	if size < 1 {
+		panic("slices: Window size cannot be less than 1")
+	}
+
+	// Preserve nil in case it matters.
+	if s == nil {
+		return nil
+	}
+
+	n := len(s) - size + 1
+	if n < 0 {
+		n = 0
+	}
+
+	r := make([]S, n)
+	for i := range r {
+		r[i] = s[i : i+size : i+size]
+	}
+	return r
+}
+
+// Partition splits the slice s into two new slices: the elements satisfy f(i, v),
+// and the ones that do not. Both keep the original order.
+// It returns nil slices if s is nil.
+func Partition[S ~[]E, E any](s S, f func(int, E) bool) (yes, no S) {
+	// Preserve nil in case it matters.
+	if s == nil {
+		return nil, nil
+	}
+
+	yes, no = make(S, 0, len(s)), make(S, 0, len(s))
+	for i, v := range s {
+		if f(i, v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+	return yes, no
+}
+
+// GroupBy groups the elements of the slice s by key(v).
+// The elements of each group keep the original order.
+// It returns nil if s is nil.
+func GroupBy[S ~[]E, E any, K comparable](s S, key func(E) K) map[K]S {
+	// Preserve nil in case it matters.
+	if s == nil {
+		return nil
+	}
+
+	r := make(map[K]S)
+	for _, v := range s {
+		k := key(v)
+		r[k] = append(r[k], v)
+	}
+	return r
+}
+
 // SliceOf returns a slice which contains the element vs.
 // If returns nil if len(vs) == 0.
 func SliceOf[E any](vs ...E) []E {
@@ -319,6 +405,11 @@ func (s Slice[E]) PFilter(f func(int, E) bool) Slice[E] {
 	return PFilter(s, f)
 }
 
+// PFilterStable returns the result of applying PFilterStable to the receiver and f.
+func (s Slice[E]) PFilterStable(f func(int, E) bool) Slice[E] {
+	return PFilterStable(s, f)
+}
+
 // ForEach applies ForEach to the receiver and f.
 func (s Slice[E]) ForEach(f func(int, E)) {
 	ForEach(s, f)
@@ -329,6 +420,21 @@ func (s Slice[E]) PForEach(f func(int, E)) {
 	PForEach(s, f)
 }
 
+// Chunk returns the result of applying Chunk to the receiver and size.
+func (s Slice[E]) Chunk(size int) []Slice[E] {
+	return Chunk(s, size)
+}
+
+// Window returns the result of applying Window to the receiver and size.
+func (s Slice[E]) Window(size int) []Slice[E] {
+	return Window(s, size)
+}
+
+// Partition returns the result of applying Partition to the receiver and f.
+func (s Slice[E]) Partition(f func(int, E) bool) (yes, no Slice[E]) {
+	return Partition(s, f)
+}
+
 // Shuffle returns the result of applying Shuffle to the receiver.
 func (s Slice[E]) Shuffle() Slice[E] {
 	return Shuffle(s)
@@ -364,6 +470,11 @@ func (s ComparableSlice[E]) PFilter(f func(int, E) bool) ComparableSlice[E] {
 	return PFilter(s, f)
 }
 
+// PFilterStable returns the result of applying PFilterStable to the receiver and f.
+func (s ComparableSlice[E]) PFilterStable(f func(int, E) bool) ComparableSlice[E] {
+	return PFilterStable(s, f)
+}
+
 // ForEach applies ForEach to the receiver and f.
 func (s ComparableSlice[E]) ForEach(f func(int, E)) {
 	ForEach(s, f)
@@ -374,6 +485,21 @@ func (s ComparableSlice[E]) PForEach(f func(int, E)) {
 	PForEach(s, f)
 }
 
+// Chunk returns the result of applying Chunk to the receiver and size.
+func (s ComparableSlice[E]) Chunk(size int) []ComparableSlice[E] {
+	return Chunk(s, size)
+}
+
+// Window returns the result of applying Window to the receiver and size.
+func (s ComparableSlice[E]) Window(size int) []ComparableSlice[E] {
+	return Window(s, size)
+}
+
+// Partition returns the result of applying Partition to the receiver and f.
+func (s ComparableSlice[E]) Partition(f func(int, E) bool) (yes, no ComparableSlice[E]) {
+	return Partition(s, f)
+}
+
 // Shuffle returns the result of applying Shuffle to the receiver.
 func (s ComparableSlice[E]) Shuffle() ComparableSlice[E] {
 	return Shuffle(s)
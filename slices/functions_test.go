@@ -49,6 +49,46 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+func TestPFilterStable(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range filterTests {
+		got := PFilterStable(tc.s, tc.f)
+		if fmt.Sprintf("%#v", tc.want) != fmt.Sprintf("%#v", got) {
+			t.Errorf("PFilterStable(%#v) = %#v, want %#v", tc.s, got, tc.want)
+		}
+	}
+
+	s := sliceGenerator(10_000)
+	f := func(i int, v int) bool { return v%3 != 0 }
+	if got, want := PFilterStable(s, f), Filter(s, f); !Equal(want, got) {
+		t.Errorf("PFilterStable() = %v, want %v", got, want)
+	}
+}
+
+func BenchmarkPFilter(b *testing.B) {
+	s := sliceGenerator(1000_000)
+	f := func(_ int, v int) bool { return v%2 == 0 }
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Filter(s, f)
+		}
+	})
+
+	b.Run("channel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = PFilter(s, f)
+		}
+	})
+
+	b.Run("stable", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = PFilterStable(s, f)
+		}
+	})
+}
+
 func TestMap(t *testing.T) {
 	t.Parallel()
 
@@ -174,6 +214,78 @@ func TestReduce(t *testing.T) {
 	}
 }
 
+func TestPReduce(t *testing.T) {
+	t.Parallel()
+
+	sum := func(acc int, _ int, v int) int { return acc + v }
+	add := func(a, b int) int { return a + b }
+	zero := func() int { return 0 }
+
+	testcases := []struct {
+		s    []int
+		opts []Option
+		want int
+	}{
+		{nil, nil, 0},
+		{[]int{1, 2, 3, 4}, nil, 10},
+		{[]int{1, 2, 3, 4, 5}, []Option{WithChunkSize(2)}, 15},
+		{[]int{1, 2, 3, 4, 5}, []Option{WithConcurrency(1)}, 15},
+	}
+
+	for _, tc := range testcases {
+		if got := PReduce(tc.s, sum, add, zero, tc.opts...); tc.want != got {
+			t.Errorf("PReduce(%#v) = %v, want %v", tc.s, got, tc.want)
+		}
+	}
+
+	// Partial results are merged in order, so a non-commutative
+	// but associative combiner gives the sequential result.
+	s := RepeatFunc(func(i int) string { return strconv.Itoa(i % 10) }, 1000)
+	concat := func(acc string, _ int, v string) string { return acc + v }
+	want := Reduce(s, concat, "")
+	for _, size := range []int{0, 1, 7, 100} {
+		got := PReduce(s, concat, func(a, b string) string { return a + b }, func() string { return "" }, WithChunkSize(size))
+		if want != got {
+			t.Errorf("PReduce(WithChunkSize(%d)) = %q, want %q", size, got, want)
+		}
+	}
+
+	// Each chunk gets its own accumulator, so f and combine may modify it.
+	ints := RepeatFunc(func(i int) int { return i % 7 }, 100_000)
+	hist := PReduce(ints,
+		func(m map[int]int, _ int, v int) map[int]int { m[v]++; return m },
+		func(a, b map[int]int) map[int]int {
+			for k, n := range b {
+				a[k] += n
+			}
+			return a
+		},
+		func() map[int]int { return map[int]int{} },
+		WithConcurrency(4), WithChunkSize(1000))
+	for k := 0; k < 7; k++ {
+		if want := len(IndexAll(ints, k)); hist[k] != want {
+			t.Errorf("PReduce(histogram)[%d] = %d, want %d", k, hist[k], want)
+		}
+	}
+}
+
+func BenchmarkReduce(b *testing.B) {
+	s := sliceGenerator(1000_000)
+	sum := func(acc int, _ int, v int) int { return acc + v }
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Reduce(s, sum, 0)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = PReduce(s, sum, func(a, b int) int { return a + b }, func() int { return 0 })
+		}
+	})
+}
+
 var forEachTests = []struct {
 	s []int
 }{
@@ -507,6 +619,113 @@ func TestMin(t *testing.T) {
 	}
 }
 
+var chunkTests = []struct {
+	s    []int
+	size int
+	want [][]int
+}{
+	{nil, 2, nil},
+	{[]int{}, 2, [][]int{}},
+	{[]int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+	{[]int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+	{[]int{1, 2, 3}, 5, [][]int{{1, 2, 3}}},
+}
+
+func TestChunk(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range chunkTests {
+		got := Chunk(tc.s, tc.size)
+		if fmt.Sprintf("%#v", tc.want) != fmt.Sprintf("%#v", got) {
+			t.Errorf("Chunk(%#v, %d) = %#v, want %#v", tc.s, tc.size, got, tc.want)
+		}
+	}
+
+	s := []int{1, 2, 3, 4}
+	chunks := Chunk(s, 2)
+	chunks[0][0] = 10
+	if s[0] != 10 {
+		t.Errorf("Chunk(%v, 2) does not share the underlying array", s)
+	}
+	_ = append(chunks[0], 30)
+	if s[2] != 3 {
+		t.Errorf("appending to Chunk(%v, 2)[0] overwrote the next chunk", s)
+	}
+
+	if !panics(func() { Chunk(s, 0) }) {
+		t.Errorf("Chunk(%v, 0): got no panic, want panic", s)
+	}
+}
+
+var windowTests = []struct {
+	s    []int
+	size int
+	want [][]int
+}{
+	{nil, 2, nil},
+	{[]int{1}, 2, [][]int{}},
+	{[]int{1, 2}, 2, [][]int{{1, 2}}},
+	{[]int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {2, 3}, {3, 4}}},
+	{[]int{1, 2, 3, 4}, 3, [][]int{{1, 2, 3}, {2, 3, 4}}},
+}
+
+func TestWindow(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range windowTests {
+		got := Window(tc.s, tc.size)
+		if fmt.Sprintf("%#v", tc.want) != fmt.Sprintf("%#v", got) {
+			t.Errorf("Window(%#v, %d) = %#v, want %#v", tc.s, tc.size, got, tc.want)
+		}
+	}
+
+	s := []int{1, 2, 3}
+	_ = append(Window(s, 2)[0], 30)
+	if s[2] != 3 {
+		t.Errorf("appending to Window(%v, 2)[0] overwrote the slice", s)
+	}
+
+	if !panics(func() { Window(s, 0) }) {
+		t.Errorf("Window(%v, 0): got no panic, want panic", s)
+	}
+}
+
+var partitionTests = []struct {
+	s       []int
+	f       func(int, int) bool
+	yes, no []int
+}{
+	{nil, func(int, int) bool { return true }, nil, nil},
+	{[]int{}, func(int, int) bool { return true }, []int{}, []int{}},
+	{[]int{0, 1, 2, 3, 4}, func(_ int, v int) bool { return v&0x1 == 0 }, []int{0, 2, 4}, []int{1, 3}},
+}
+
+func TestPartition(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range partitionTests {
+		yes, no := Partition(tc.s, tc.f)
+		if fmt.Sprintf("%#v %#v", tc.yes, tc.no) != fmt.Sprintf("%#v %#v", yes, no) {
+			t.Errorf("Partition(%#v) = %#v, %#v, want %#v, %#v", tc.s, yes, no, tc.yes, tc.no)
+		}
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	t.Parallel()
+
+	if got := GroupBy([]string(nil), func(v string) int { return len(v) }); got != nil {
+		t.Errorf("GroupBy(nil) = %#v, want nil", got)
+	}
+
+	s := []string{"a", "bb", "c", "dd", "eee"}
+	got := GroupBy(s, func(v string) int { return len(v) })
+	want := map[int][]string{1: {"a", "c"}, 2: {"bb", "dd"}, 3: {"eee"}}
+	if fmt.Sprint(want) != fmt.Sprint(got) {
+		t.Errorf("GroupBy(%v) = %v, want %v", s, got, want)
+	}
+}
+
 func TestSliceOf(t *testing.T) {
 	t.Parallel()
 
@@ -546,6 +765,56 @@ func TestSlice_Filter(t *testing.T) {
 		if fmt.Sprintf("%#v", tc.want) != fmt.Sprintf("%#v", []int(got)) {
 			t.Errorf("%#v.PFilter() = %#v, want %#v", tc.s, got, tc.want)
 		}
+
+		got = NewSlice(tc.s).PFilterStable(tc.f)
+		if fmt.Sprintf("%#v", tc.want) != fmt.Sprintf("%#v", []int(got)) {
+			t.Errorf("%#v.PFilterStable() = %#v, want %#v", tc.s, got, tc.want)
+		}
+	}
+}
+
+func TestSlice_Chunk(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range chunkTests {
+		got := NewSlice(tc.s).Chunk(tc.size)
+		if len(tc.want) != len(got) {
+			t.Errorf("%#v.Chunk(%d) = %#v, want %#v", tc.s, tc.size, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if !Equal(tc.want[i], got[i]) {
+				t.Errorf("%#v.Chunk(%d) = %#v, want %#v", tc.s, tc.size, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestSlice_Window(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range windowTests {
+		got := NewSlice(tc.s).Window(tc.size)
+		if len(tc.want) != len(got) {
+			t.Errorf("%#v.Window(%d) = %#v, want %#v", tc.s, tc.size, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if !Equal(tc.want[i], got[i]) {
+				t.Errorf("%#v.Window(%d) = %#v, want %#v", tc.s, tc.size, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestSlice_Partition(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range partitionTests {
+		yes, no := NewSlice(tc.s).Partition(tc.f)
+		if fmt.Sprintf("%#v %#v", tc.yes, tc.no) != fmt.Sprintf("%#v %#v", []int(yes), []int(no)) {
+			t.Errorf("%#v.Partition() = %#v, %#v, want %#v, %#v", tc.s, yes, no, tc.yes, tc.no)
+		}
 	}
 }
 
@@ -0,0 +1,185 @@
+package slices
+
+// LastIndex returns the index of the last occurrence of v in s,
+// or -1 if not present.
+func LastIndex[E comparable](s []E, v E) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if v == s[i] {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// LastIndexFunc returns the last index i satisfying f(s[i]),
+// or -1 if none do.
+func LastIndexFunc[E any](s []E, f func(E) bool) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if f(s[i]) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// IndexAll returns the indexes of all the occurrences of v in s,
+// in increasing order, or nil if not present.
+func IndexAll[E comparable](s []E, v E) []int {
+	var r []int
+	for i := range s {
+		if v == s[i] {
+			r = append(r, i)
+		}
+	}
+	return r
+}
+
+// IndexAllFunc returns all the indexes i satisfying f(s[i]),
+// in increasing order, or nil if none do.
+func IndexAllFunc[E any](s []E, f func(E) bool) []int {
+	var r []int
+	for i := range s {
+		if f(s[i]) {
+			r = append(r, i)
+		}
+	}
+	return r
+}
+
+// IndexSlice returns the index of the first occurrence of the subsequence sub in s,
+// or -1 if not present. It returns 0 if sub is empty.
+// It uses the Knuth-Morris-Pratt algorithm, which is O(len(s) + len(sub))
+// and allocates a table of len(sub) ints.
+func IndexSlice[E comparable](s, sub []E) int {
+	switch {
+	case len(sub) == 1:
+		return Index(s, sub[0])
+	case len(sub) > len(s):
+		return -1
+	}
+
+	return indexSliceFunc(s, sub, func(a, b E) bool { return a == b })
+}
+
+// IndexSliceFunc is like IndexSlice but uses eq to compare elements,
+// which must be an equivalence relation: the elements of sub are
+// also compared with each other.
+func IndexSliceFunc[E any](s, sub []E, eq func(E, E) bool) int {
+	if len(sub) > len(s) {
+		return -1
+	}
+
+	return indexSliceFunc(s, sub, eq)
+}
+
+// ContainsSlice reports whether the subsequence sub is present in s.
+func ContainsSlice[E comparable](s, sub []E) bool {
+	return IndexSlice(s, sub) >= 0
+}
+
+// ContainsSliceFunc reports whether the subsequence sub is present in s,
+// using eq to compare elements as IndexSliceFunc does.
+func ContainsSliceFunc[E any](s, sub []E, eq func(E, E) bool) bool {
+	return IndexSliceFunc(s, sub, eq) >= 0
+}
+
+// indexSliceFunc returns the index of the first occurrence of sub in s, or -1,
+// using the Knuth-Morris-Pratt algorithm.
+func indexSliceFunc[E any](s, sub []E, eq func(E, E) bool) int {
+	m := len(sub)
+	if m == 0 {
+		return 0
+	}
+
+	// border[i] is the length of the longest proper prefix of sub[:i+1]
+	// which is also a suffix of it.
+	border := make([]int, m)
+	for i, k := 1, 0; i < m; i++ {
+		for k > 0 && !eq(sub[i], sub[k]) {
+			k = border[k-1]
+		}
+		if eq(sub[i], sub[k]) {
+			k++
+		}
+		border[i] = k
+	}
+
+	// k is the length of the prefix of sub matched so far.
+	for i, k := 0, 0; i < len(s); i++ {
+		for k > 0 && !eq(s[i], sub[k]) {
+			k = border[k-1]
+		}
+		if eq(s[i], sub[k]) {
+			k++
+		}
+		if k == m {
+			return i - m + 1
+		}
+	}
+
+	return -1
+}
+
+// Convenience wrappers for common cases.
+
+// LastIndexFunc returns the result of applying LastIndexFunc to the receiver and f.
+func (s Slice[E]) LastIndexFunc(f func(E) bool) int {
+	return LastIndexFunc(s, f)
+}
+
+// IndexAllFunc returns the result of applying IndexAllFunc to the receiver and f.
+func (s Slice[E]) IndexAllFunc(f func(E) bool) []int {
+	return IndexAllFunc(s, f)
+}
+
+// IndexSliceFunc returns the result of applying IndexSliceFunc to the receiver, sub and eq.
+func (s Slice[E]) IndexSliceFunc(sub []E, eq func(E, E) bool) int {
+	return IndexSliceFunc(s, sub, eq)
+}
+
+// ContainsSliceFunc returns the result of applying ContainsSliceFunc to the receiver, sub and eq.
+func (s Slice[E]) ContainsSliceFunc(sub []E, eq func(E, E) bool) bool {
+	return ContainsSliceFunc(s, sub, eq)
+}
+
+// LastIndex returns the result of applying LastIndex to the receiver and v.
+func (s ComparableSlice[E]) LastIndex(v E) int {
+	return LastIndex(s, v)
+}
+
+// LastIndexFunc returns the result of applying LastIndexFunc to the receiver and f.
+func (s ComparableSlice[E]) LastIndexFunc(f func(E) bool) int {
+	return LastIndexFunc(s, f)
+}
+
+// IndexAll returns the result of applying IndexAll to the receiver and v.
+func (s ComparableSlice[E]) IndexAll(v E) []int {
+	return IndexAll(s, v)
+}
+
+// IndexAllFunc returns the result of applying IndexAllFunc to the receiver and f.
+func (s ComparableSlice[E]) IndexAllFunc(f func(E) bool) []int {
+	return IndexAllFunc(s, f)
+}
+
+// IndexSlice returns the result of applying IndexSlice to the receiver and sub.
+func (s ComparableSlice[E]) IndexSlice(sub []E) int {
+	return IndexSlice(s, sub)
+}
+
+// IndexSliceFunc returns the result of applying IndexSliceFunc to the receiver, sub and eq.
+func (s ComparableSlice[E]) IndexSliceFunc(sub []E, eq func(E, E) bool) int {
+	return IndexSliceFunc(s, sub, eq)
+}
+
+// ContainsSlice returns the result of applying ContainsSlice to the receiver and sub.
+func (s ComparableSlice[E]) ContainsSlice(sub []E) bool {
+	return ContainsSlice(s, sub)
+}
+
+// ContainsSliceFunc returns the result of applying ContainsSliceFunc to the receiver, sub and eq.
+func (s ComparableSlice[E]) ContainsSliceFunc(sub []E, eq func(E, E) bool) bool {
+	return ContainsSliceFunc(s, sub, eq)
+}
@@ -0,0 +1,139 @@
+package slices_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+var lastIndexTests = []struct {
+	s    []int
+	v    int
+	last int
+	all  []int
+}{
+	{nil, 0, -1, nil},
+	{[]int{}, 0, -1, nil},
+	{[]int{1, 2, 3}, 2, 1, []int{1}},
+	{[]int{1, 2, 2, 3, 2}, 2, 4, []int{1, 2, 4}},
+	{[]int{1, 2, 3}, 4, -1, nil},
+}
+
+func TestLastIndex(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range lastIndexTests {
+		if got := LastIndex(tc.s, tc.v); got != tc.last {
+			t.Errorf("LastIndex(%v, %d) = %d, want %d", tc.s, tc.v, got, tc.last)
+		}
+		f := func(v int) bool { return v == tc.v }
+		if got := LastIndexFunc(tc.s, f); got != tc.last {
+			t.Errorf("LastIndexFunc(%v, equal %d) = %d, want %d", tc.s, tc.v, got, tc.last)
+		}
+		if got := IndexAll(tc.s, tc.v); !Equal(got, tc.all) || (got == nil) != (tc.all == nil) {
+			t.Errorf("IndexAll(%v, %d) = %v, want %v", tc.s, tc.v, got, tc.all)
+		}
+		if got := IndexAllFunc(tc.s, f); !Equal(got, tc.all) {
+			t.Errorf("IndexAllFunc(%v, equal %d) = %v, want %v", tc.s, tc.v, got, tc.all)
+		}
+	}
+}
+
+var indexSliceTests = []struct {
+	s, sub string
+	want   int
+}{
+	{"", "", 0},
+	{"abc", "", 0},
+	{"", "a", -1},
+	{"abc", "abcd", -1},
+	{"abc", "c", 2},
+	{"abc", "abc", 0},
+	{"aaab", "aab", 1},
+	{"abababc", "ababc", 2},
+	{"abcabdabcabcabd", "abcabd", 0},
+	{"xabcabcabd", "abcabd", 4},
+	{"aabaabaaa", "aabaaa", 3},
+	{"abcab", "abd", -1},
+}
+
+func TestIndexSlice(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range indexSliceTests {
+		s, sub := []byte(tc.s), []byte(tc.sub)
+		if got := IndexSlice(s, sub); got != tc.want {
+			t.Errorf("IndexSlice(%q, %q) = %d, want %d", tc.s, tc.sub, got, tc.want)
+		}
+		if got := ContainsSlice(s, sub); got != (tc.want >= 0) {
+			t.Errorf("ContainsSlice(%q, %q) = %t, want %t", tc.s, tc.sub, got, tc.want >= 0)
+		}
+
+		// Case-insensitive comparison.
+		eq := func(a, b byte) bool { return a|0x20 == b|0x20 }
+		upper := []byte(strings.ToUpper(tc.sub))
+		if got := IndexSliceFunc(s, upper, eq); got != tc.want {
+			t.Errorf("IndexSliceFunc(%q, %q, equal fold) = %d, want %d", tc.s, upper, got, tc.want)
+		}
+		if got := ContainsSliceFunc(s, upper, eq); got != (tc.want >= 0) {
+			t.Errorf("ContainsSliceFunc(%q, %q, equal fold) = %t, want %t", tc.s, upper, got, tc.want >= 0)
+		}
+	}
+}
+
+func TestIndexSlice_Random(t *testing.T) {
+	t.Parallel()
+
+	naive := func(s, sub []int) int {
+		for i := 0; i+len(sub) <= len(s); i++ {
+			if Equal(s[i:i+len(sub)], sub) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for i := 0; i < 1000; i++ {
+		s, sub := make([]int, rand.Intn(30)), make([]int, rand.Intn(5))
+		for i := range s {
+			s[i] = rand.Intn(2)
+		}
+		for i := range sub {
+			sub[i] = rand.Intn(2)
+		}
+		if got, want := IndexSlice(s, sub), naive(s, sub); got != want {
+			t.Fatalf("IndexSlice(%v, %v) = %d, want %d", s, sub, got, want)
+		}
+	}
+}
+
+// Tests for convenience wrappers.
+
+func TestComparableSlice_IndexSlice(t *testing.T) {
+	t.Parallel()
+
+	s := NewComparableSlice([]int{1, 2, 3, 1, 2, 3})
+	if got := s.LastIndex(2); got != 4 {
+		t.Errorf("%v.LastIndex(2) = %d, want 4", s, got)
+	}
+	if got, want := s.IndexAll(3), []int{2, 5}; !Equal(got, want) {
+		t.Errorf("%v.IndexAll(3) = %v, want %v", s, got, want)
+	}
+	if got := s.IndexSlice([]int{3, 1}); got != 2 {
+		t.Errorf("%v.IndexSlice([3 1]) = %d, want 2", s, got)
+	}
+	if s.ContainsSlice([]int{3, 2}) {
+		t.Errorf("%v.ContainsSlice([3 2]) = true, want false", s)
+	}
+
+	byParity := func(a, b int) bool { return a%2 == b%2 }
+	p := NewSlice([]int{1, 3, 2, 4})
+	if got := p.IndexSliceFunc([]int{5, 6}, byParity); got != 1 {
+		t.Errorf("%v.IndexSliceFunc([5 6], byParity) = %d, want 1", p, got)
+	}
+	if got := p.LastIndexFunc(func(v int) bool { return v%2 == 1 }); got != 1 {
+		t.Errorf("%v.LastIndexFunc(odd) = %d, want 1", p, got)
+	}
+}
@@ -0,0 +1,174 @@
+package slices
+
+// The functions below treat a slice of slices [][]E as a matrix of rows.
+// The ones building a new matrix allocate its cells in a single array,
+// each row being a slice of it whose capacity is clipped, so appending
+// to a row never overwrites the next one.
+
+// MakeMatrix returns a new matrix of rows rows and cols columns of zero values,
+// with its cells allocated in a single array.
+// It panics if rows or cols is negative.
+func MakeMatrix[E any](rows, cols int) [][]E {
+	if rows < 0 || cols < 0 {
+		panic("slices: negative matrix dimension")
+	}
+
+	return matrixOf(make([]E, rows*cols), rows, cols)
+}
+
+// CloneMatrix returns a copy of the matrix m, whose rows are copied with Clone,
+// so it may be ragged. It returns nil if m is nil.
+func CloneMatrix[S ~[]E, E any](m []S) []S {
+	// Preserve nil in case it matters.
+	if m == nil {
+		return nil
+	}
+
+	r := make([]S, len(m))
+	for i, row := range m {
+		r[i] = Clone(row)
+	}
+	return r
+}
+
+// Row returns a copy of the row i of the matrix m, made with Clone.
+// It panics if i is out of range.
+func Row[S ~[]E, E any](m []S, i int) S {
+	return Clone(m[i])
+}
+
+// Column returns a new slice of the elements of the column j of the matrix m,
+// one per row. It returns nil if m is nil, and panics if j is out of range
+// for a row.
+func Column[S ~[]E, E any](m []S, j int) S {
+	// Preserve nil in case it matters.
+	if m == nil {
+		return nil
+	}
+
+	r := make(S, len(m))
+	for i, row := range m {
+		r[i] = row[j]
+	}
+	return r
+}
+
+// Transpose returns a new matrix whose row j is the column j of the matrix m.
+// It returns nil if m is nil, and panics if m is ragged.
+func Transpose[S ~[]E, E any](m []S) []S {
+	// Preserve nil in case it matters.
+	if m == nil {
+		return nil
+	}
+
+	rows, cols := matrixDims(m)
+	r := matrixOf(make(S, rows*cols), cols, rows)
+	for i, row := range m {
+		for j, v := range row {
+			r[j][i] = v
+		}
+	}
+	return r
+}
+
+// Rotate90 returns a new matrix which is the matrix m rotated by 90 degrees clockwise:
+// the first row of the result is the first column of m, from bottom to top.
+// Rotate90 can be applied twice or three times to rotate by 180 or 270 degrees.
+// It returns nil if m is nil, and panics if m is ragged.
+func Rotate90[S ~[]E, E any](m []S) []S {
+	// Preserve nil in case it matters.
+	if m == nil {
+		return nil
+	}
+
+	rows, cols := matrixDims(m)
+	r := matrixOf(make(S, rows*cols), cols, rows)
+	for i, row := range m {
+		for j, v := range row {
+			r[j][rows-1-i] = v
+		}
+	}
+	return r
+}
+
+// MapMatrix returns a new matrix of the results of applying f(i, j, v)
+// to the cells v = m[i][j] of the matrix m, with the same shape, which may be ragged.
+// It returns nil if m is nil.
+func MapMatrix[S ~[]E1, E1, E2 any](m []S, f func(i, j int, v E1) E2) [][]E2 {
+	// Preserve nil in case it matters.
+	if m == nil {
+		return nil
+	}
+
+	n := 0
+	for _, row := range m {
+		n += len(row)
+	}
+
+	cells := make([]E2, n)
+	r := make([][]E2, len(m))
+	for i, row := range m {
+		r[i], cells = cells[:len(row):len(row)], cells[len(row):]
+		for j, v := range row {
+			r[i][j] = f(i, j, v)
+		}
+	}
+	return r
+}
+
+// Unflatten returns the slice s split into rows of cols elements,
+// reversing Flatten for a matrix which is not ragged. Like Chunk, the rows
+// share the underlying array of s, but their capacity is clipped.
+// It returns nil if s is nil, and panics if cols < 1 or if len(s)
+// is not a multiple of cols.
+func Unflatten[S ~[]E, E any](s S, cols int) []S {
+	if cols < 1 {
+		panic("slices: Unflatten cols cannot be less than 1")
+	}
+	if len(s)%cols != 0 {
+		panic("slices: Unflatten length is not a multiple of cols")
+	}
+
+	// Preserve nil in case it matters.
+	if s == nil {
+		return nil
+	}
+
+	return matrixOf(s, len(s)/cols, cols)
+}
+
+// matrixOf returns s, of length rows*cols, split into rows rows of cols elements.
+func matrixOf[S ~[]E, E any](s S, rows, cols int) []S {
+	r := make([]S, rows)
+	for i := range r {
+		r[i] = s[i*cols : (i+1)*cols : (i+1)*cols]
+	}
+	return r
+}
+
+// matrixDims returns the number of rows and columns of the matrix m,
+// and panics if m is ragged.
+func matrixDims[S ~[]E, E any](m []S) (rows, cols int) {
+	rows = len(m)
+	if rows > 0 {
+		cols = len(m[0])
+	}
+	for _, row := range m {
+		if len(row) != cols {
+			panic("slices: ragged matrix")
+		}
+	}
+	return rows, cols
+}
+
+// Convenience wrappers for common cases.
+
+// Unflatten returns the result of applying Unflatten to the receiver and cols.
+func (s Slice[E]) Unflatten(cols int) []Slice[E] {
+	return Unflatten(s, cols)
+}
+
+// Unflatten returns the result of applying Unflatten to the receiver and cols.
+func (s ComparableSlice[E]) Unflatten(cols int) []ComparableSlice[E] {
+	return Unflatten(s, cols)
+}
@@ -0,0 +1,172 @@
+package slices_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+func equalMatrix(m1, m2 [][]int) bool {
+	return EqualFunc(m1, m2, func(r1, r2 []int) bool { return Equal(r1, r2) })
+}
+
+var matrixTests = []struct {
+	m, transpose, rotate [][]int
+}{
+	{nil, nil, nil},
+	{[][]int{}, [][]int{}, [][]int{}},
+	{[][]int{{1}}, [][]int{{1}}, [][]int{{1}}},
+	{
+		[][]int{{1, 2, 3}, {4, 5, 6}},
+		[][]int{{1, 4}, {2, 5}, {3, 6}},
+		[][]int{{4, 1}, {5, 2}, {6, 3}},
+	},
+}
+
+func TestTranspose(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range matrixTests {
+		got := Transpose(tc.m)
+		if !equalMatrix(got, tc.transpose) || (got == nil) != (tc.m == nil) {
+			t.Errorf("Transpose(%v) = %v, want %v", tc.m, got, tc.transpose)
+		}
+		if tc.m != nil && !equalMatrix(Transpose(got), tc.m) {
+			t.Errorf("Transpose(Transpose(%v)) = %v, want %v", tc.m, Transpose(got), tc.m)
+		}
+	}
+
+	if !panics(func() { Transpose([][]int{{1, 2}, {3}}) }) {
+		t.Errorf("Transpose(ragged): got no panic, want panic")
+	}
+}
+
+func TestRotate90(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range matrixTests {
+		got := Rotate90(tc.m)
+		if !equalMatrix(got, tc.rotate) || (got == nil) != (tc.m == nil) {
+			t.Errorf("Rotate90(%v) = %v, want %v", tc.m, got, tc.rotate)
+		}
+		if full := Rotate90(Rotate90(Rotate90(got))); !equalMatrix(full, tc.m) {
+			t.Errorf("Rotate90 applied 4 times to %v = %v", tc.m, full)
+		}
+	}
+
+	// Appending to a row does not overwrite the next one.
+	m := Rotate90([][]int{{1, 2}, {3, 4}})
+	_ = append(m[0], 0)
+	if m[1][0] != 4 {
+		t.Errorf("appending to a row of Rotate90 modified the next row")
+	}
+}
+
+func TestRowColumn(t *testing.T) {
+	t.Parallel()
+
+	m := [][]int{{1, 2, 3}, {4, 5, 6}}
+	row := Row(m, 1)
+	if want := []int{4, 5, 6}; !Equal(row, want) {
+		t.Errorf("Row(%v, 1) = %v, want %v", m, row, want)
+	}
+	row[0] = 99
+	if m[1][0] != 4 {
+		t.Errorf("Row returned a row sharing m")
+	}
+
+	if got, want := Column(m, 2), []int{3, 6}; !Equal(got, want) {
+		t.Errorf("Column(%v, 2) = %v, want %v", m, got, want)
+	}
+	if got := Column([][]int(nil), 0); got != nil {
+		t.Errorf("Column(nil, 0) = %v, want nil", got)
+	}
+	if !panics(func() { Column(m, 3) }) {
+		t.Errorf("Column(%v, 3): got no panic, want panic", m)
+	}
+}
+
+func TestCloneMatrix(t *testing.T) {
+	t.Parallel()
+
+	if got := CloneMatrix([][]int(nil)); got != nil {
+		t.Errorf("CloneMatrix(nil) = %v, want nil", got)
+	}
+
+	m := [][]int{{1, 2}, nil, {3}}
+	c := CloneMatrix(m)
+	if !equalMatrix(c, m) || c[1] != nil {
+		t.Errorf("CloneMatrix(%v) = %v", m, c)
+	}
+	c[0][0] = 99
+	if m[0][0] != 1 {
+		t.Errorf("CloneMatrix returned rows sharing m")
+	}
+}
+
+func TestMapMatrix(t *testing.T) {
+	t.Parallel()
+
+	if got := MapMatrix([][]int(nil), func(i, j, v int) string { return "" }); got != nil {
+		t.Errorf("MapMatrix(nil) = %v, want nil", got)
+	}
+
+	m := [][]int{{1, 2}, {}, {3}}
+	got := MapMatrix(m, func(i, j, v int) string { return fmt.Sprintf("%d%d:%d", i, j, v) })
+	want := [][]string{{"00:1", "01:2"}, {}, {"20:3"}}
+	if !EqualFunc(got, want, func(r1, r2 []string) bool { return Equal(r1, r2) }) {
+		t.Errorf("MapMatrix(%v) = %v, want %v", m, got, want)
+	}
+}
+
+func TestMakeMatrix(t *testing.T) {
+	t.Parallel()
+
+	m := MakeMatrix[int](2, 3)
+	if len(m) != 2 || len(m[0]) != 3 || len(m[1]) != 3 {
+		t.Fatalf("MakeMatrix(2, 3) = %v", m)
+	}
+	if !panics(func() { MakeMatrix[int](-1, 3) }) {
+		t.Errorf("MakeMatrix(-1, 3): got no panic, want panic")
+	}
+}
+
+func TestUnflatten(t *testing.T) {
+	t.Parallel()
+
+	if got := Unflatten([]int(nil), 3); got != nil {
+		t.Errorf("Unflatten(nil, 3) = %v, want nil", got)
+	}
+
+	m := [][]int{{1, 2, 3}, {4, 5, 6}}
+	s := Flatten(m)
+	if got := Unflatten(s, 3); !equalMatrix(got, m) {
+		t.Errorf("Unflatten(%v, 3) = %v, want %v", s, got, m)
+	}
+	if got, want := Unflatten(s, 2), [][]int{{1, 2}, {3, 4}, {5, 6}}; !equalMatrix(got, want) {
+		t.Errorf("Unflatten(%v, 2) = %v, want %v", s, got, want)
+	}
+
+	for _, cols := range []int{0, 4} {
+		if !panics(func() { Unflatten(s, cols) }) {
+			t.Errorf("Unflatten(%v, %d): got no panic, want panic", s, cols)
+		}
+	}
+}
+
+// Tests for convenience wrappers.
+
+func TestSlice_Unflatten(t *testing.T) {
+	t.Parallel()
+
+	s := NewSlice([]int{1, 2, 3, 4})
+	if got := s.Unflatten(2); len(got) != 2 || !Equal(got[1], []int{3, 4}) {
+		t.Errorf("%v.Unflatten(2) = %v", s, got)
+	}
+
+	c := NewComparableSlice([]int{1, 2, 3, 4})
+	if got := c.Unflatten(4); len(got) != 1 || !Equal(got[0], []int{1, 2, 3, 4}) {
+		t.Errorf("%v.Unflatten(4) = %v", c, got)
+	}
+}
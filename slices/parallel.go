@@ -0,0 +1,318 @@
+package slices
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+)
+
+// PanicError is the value re-panicked on the calling goroutine when the function
+// passed to a parallel function such as PMap panics in a worker goroutine.
+type PanicError struct {
+	// Value is the value the worker goroutine panicked with.
+	Value any
+
+	// Stack is the stack trace of the worker goroutine at the time of the panic.
+	Stack []byte
+}
+
+// Error returns the panic value followed by the stack trace of the worker goroutine.
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("%v\n\nworker goroutine stack:\n%s", p.Value, p.Stack)
+}
+
+// Unwrap returns the panic value if it is an error, or nil otherwise.
+func (p *PanicError) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
+}
+
+// Option configures the parallel functions such as PMapCtx.
+type Option func(*options)
+
+type options struct {
+	concurrency int
+	chunkSize   int
+}
+
+// WithConcurrency sets the maximum number of goroutines used to process the slice.
+// If n <= 0, runtime.NumCPU() goroutines are used, which is the default.
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		o.concurrency = n
+	}
+}
+
+// WithChunkSize sets the number of consecutive elements a goroutine processes
+// before picking up the next chunk of work.
+// If n <= 0, the slice is split evenly among the goroutines, which is the default.
+func WithChunkSize(n int) Option {
+	return func(o *options) {
+		o.chunkSize = n
+	}
+}
+
+// newOptions returns the options for processing n elements,
+// with the defaults filled in.
+func newOptions(n int, opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.concurrency <= 0 {
+		o.concurrency = runtime.NumCPU()
+	}
+
+	if o.chunkSize <= 0 {
+		o.chunkSize = (n + o.concurrency - 1) / o.concurrency
+		if o.chunkSize == 0 {
+			o.chunkSize = 1
+		}
+	}
+
+	return o
+}
+
+// forEachChunk splits [0, n) into chunks as described by o, and calls f
+// for every chunk [start, end) on at most o.concurrency goroutines.
+// The context passed to f is canceled as soon as a call of f returns an error,
+// panics or ctx is done, and no new chunks are started after that.
+// forEachChunk returns the first error encountered, or nil.
+// If a call of f panicked, forEachChunk panics with a *PanicError on the calling goroutine
+// once all the goroutines have stopped.
+func forEachChunk(ctx context.Context, n int, o options, f func(ctx context.Context, start, end int) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	nchunks := (n + o.chunkSize - 1) / o.chunkSize
+	ngoroutines := o.concurrency
+	if ngoroutines > nchunks {
+		ngoroutines = nchunks
+	}
+
+	var (
+		next     atomic.Int64
+		once     sync.Once
+		firstErr error
+
+		panicOnce  sync.Once
+		firstPanic *PanicError
+	)
+
+	setErr := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(ngoroutines)
+	for g := 0; g < ngoroutines; g++ {
+		go func() {
+			defer wg.Done()
+
+			for {
+				c := int(next.Add(1) - 1)
+				if c >= nchunks {
+					return
+				}
+
+				if canceled(ctx) {
+					setErr(ctx.Err())
+					return
+				}
+
+				start := c * o.chunkSize
+				end := start + o.chunkSize
+				if end > n {
+					end = n
+				}
+
+				p, err := callChunk(ctx, start, end, f)
+				if p != nil {
+					panicOnce.Do(func() { firstPanic = p })
+					setErr(p)
+					return
+				}
+				if err != nil {
+					setErr(err)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	if firstPanic != nil {
+		panic(firstPanic)
+	}
+	return firstErr
+}
+
+// callChunk calls f(ctx, start, end) and converts a panic in f to a *PanicError.
+func callChunk(ctx context.Context, start, end int, f func(ctx context.Context, start, end int) error) (p *PanicError, err error) {
+	panicked := true
+	defer func() {
+		if panicked {
+			// recover returns nil for panic(nil), so rely on the flag instead.
+			p = &PanicError{Value: recover(), Stack: debug.Stack()}
+		}
+	}()
+
+	err = f(ctx, start, end)
+	panicked = false
+	return nil, err
+}
+
+// canceled reports whether ctx is done, without blocking.
+func canceled(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// PMapCtx is like PMap but f may fail, and it stops on the first failure.
+// f is called in a goroutine with a context that is canceled when ctx is done
+// or any call of f returns an error or panics; the elements not yet processed at that
+// point are skipped. PMapCtx returns the first error encountered, and a nil slice
+// in that case. The result keeps the same order as s.
+// If f panics, PMapCtx panics with a *PanicError.
+// The concurrency and the chunk size can be chosen through opts.
+func PMapCtx[S ~[]E1, E1, E2 any](ctx context.Context, s S, f func(context.Context, int, E1) (E2, error), opts ...Option) ([]E2, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Preserve nil in case it matters.
+	if s == nil {
+		return nil, nil
+	}
+
+	r := make([]E2, len(s))
+	err := forEachChunk(ctx, len(s), newOptions(len(s), opts), func(ctx context.Context, start, end int) error {
+		for i := start; i < end; i++ {
+			if canceled(ctx) {
+				return ctx.Err()
+			}
+
+			v, err := f(ctx, i, s[i])
+			if err != nil {
+				return err
+			}
+			r[i] = v
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// PFilterCtx is like PFilter but f may fail, and it stops on the first failure.
+// f is called in a goroutine with a context that is canceled when ctx is done
+// or any call of f returns an error or panics; the elements not yet processed at that
+// point are skipped. PFilterCtx returns the first error encountered, and a nil slice
+// in that case. Unlike PFilter, the result keeps the original order.
+// If f panics, PFilterCtx panics with a *PanicError.
+// The concurrency and the chunk size can be chosen through opts.
+func PFilterCtx[S ~[]E, E any](ctx context.Context, s S, f func(context.Context, int, E) (bool, error), opts ...Option) (S, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Preserve nil in case it matters.
+	if s == nil {
+		return nil, nil
+	}
+
+	o := newOptions(len(s), opts)
+	chunks := make([]S, (len(s)+o.chunkSize-1)/o.chunkSize)
+	err := forEachChunk(ctx, len(s), o, func(ctx context.Context, start, end int) error {
+		var r S
+		for i := start; i < end; i++ {
+			if canceled(ctx) {
+				return ctx.Err()
+			}
+
+			ok, err := f(ctx, i, s[i])
+			if err != nil {
+				return err
+			}
+			if ok {
+				r = append(r, s[i])
+			}
+		}
+		chunks[start/o.chunkSize] = r
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	n := 0
+	for _, c := range chunks {
+		n += len(c)
+	}
+
+	r := make(S, 0, n)
+	for _, c := range chunks {
+		r = append(r, c...)
+	}
+	return r, nil
+}
+
+// PForEachCtx is like PForEach but f may fail, and it stops on the first failure.
+// f is called in a goroutine with a context that is canceled when ctx is done
+// or any call of f returns an error or panics; the elements not yet processed at that
+// point are skipped. PForEachCtx returns the first error encountered.
+// If f panics, PForEachCtx panics with a *PanicError.
+// The concurrency and the chunk size can be chosen through opts.
+func PForEachCtx[S ~[]E, E any](ctx context.Context, s S, f func(context.Context, int, E) error, opts ...Option) error {
+	return forEachChunk(ctx, len(s), newOptions(len(s), opts), func(ctx context.Context, start, end int) error {
+		for i := start; i < end; i++ {
+			if canceled(ctx) {
+				return ctx.Err()
+			}
+
+			if err := f(ctx, i, s[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Convenience wrappers for common cases.
+
+// PFilterCtx returns the result of applying PFilterCtx to the receiver, ctx, f and opts.
+func (s Slice[E]) PFilterCtx(ctx context.Context, f func(context.Context, int, E) (bool, error), opts ...Option) (Slice[E], error) {
+	return PFilterCtx(ctx, s, f, opts...)
+}
+
+// PForEachCtx returns the result of applying PForEachCtx to the receiver, ctx, f and opts.
+func (s Slice[E]) PForEachCtx(ctx context.Context, f func(context.Context, int, E) error, opts ...Option) error {
+	return PForEachCtx(ctx, s, f, opts...)
+}
+
+// PFilterCtx returns the result of applying PFilterCtx to the receiver, ctx, f and opts.
+func (s ComparableSlice[E]) PFilterCtx(ctx context.Context, f func(context.Context, int, E) (bool, error), opts ...Option) (ComparableSlice[E], error) {
+	return PFilterCtx(ctx, s, f, opts...)
+}
+
+// PForEachCtx returns the result of applying PForEachCtx to the receiver, ctx, f and opts.
+func (s ComparableSlice[E]) PForEachCtx(ctx context.Context, f func(context.Context, int, E) error, opts ...Option) error {
+	return PForEachCtx(ctx, s, f, opts...)
+}
@@ -0,0 +1,332 @@
+package slices_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+var errTest = errors.New("test error")
+
+var parallelOptionsTests = []struct {
+	name string
+	opts []Option
+}{
+	{"default", nil},
+	{"concurrency 1", []Option{WithConcurrency(1)}},
+	{"concurrency 3", []Option{WithConcurrency(3)}},
+	{"chunk size 1", []Option{WithChunkSize(1)}},
+	{"chunk size 7", []Option{WithConcurrency(2), WithChunkSize(7)}},
+}
+
+func TestPMapCtx(t *testing.T) {
+	t.Parallel()
+
+	f := func(_ context.Context, _ int, v int) (string, error) { return strconv.Itoa(v), nil }
+
+	for _, tc := range parallelOptionsTests {
+		got, err := PMapCtx(context.Background(), []int(nil), f, tc.opts...)
+		if got != nil || err != nil {
+			t.Errorf("%s: PMapCtx(nil) = %#v, %v, want nil, nil", tc.name, got, err)
+		}
+
+		s := sliceGenerator(1000)
+		want := Map(s, func(_ int, v int) string { return strconv.Itoa(v) })
+		got, err = PMapCtx(context.Background(), s, f, tc.opts...)
+		if err != nil || !Equal(want, got) {
+			t.Errorf("%s: PMapCtx() = %v, %v, want %v, nil", tc.name, got, err, want)
+		}
+	}
+}
+
+func TestPMapCtx_Error(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range parallelOptionsTests {
+		s := make([]int, 10000)
+		got, err := PMapCtx(context.Background(), s, func(_ context.Context, i int, _ int) (int, error) {
+			if i == 10 {
+				return 0, errTest
+			}
+			return i, nil
+		}, tc.opts...)
+		if got != nil || !errors.Is(err, errTest) {
+			t.Errorf("%s: PMapCtx() = %v, %v, want nil, %v", tc.name, got, err, errTest)
+		}
+	}
+
+	// With a single goroutine, no element after the failing one is processed.
+	var calls atomic.Int64
+	_, err := PMapCtx(context.Background(), make([]int, 100), func(_ context.Context, i int, _ int) (int, error) {
+		calls.Add(1)
+		if i == 10 {
+			return 0, errTest
+		}
+		return i, nil
+	}, WithConcurrency(1), WithChunkSize(1))
+	if n := calls.Load(); !errors.Is(err, errTest) || n != 11 {
+		t.Errorf("PMapCtx() called f %d times, %v, want 11, %v", n, err, errTest)
+	}
+}
+
+func TestPMapCtx_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls atomic.Int64
+	got, err := PMapCtx(ctx, []int{1, 2, 3}, func(context.Context, int, int) (int, error) {
+		calls.Add(1)
+		return 0, nil
+	})
+	if got != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("PMapCtx(canceled) = %v, %v, want nil, %v", got, err, context.Canceled)
+	}
+	if n := calls.Load(); n != 0 {
+		t.Errorf("PMapCtx(canceled) called f %d times, want 0", n)
+	}
+}
+
+func TestPCtx_CanceledNil(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	id := func(_ context.Context, _ int, v int) (int, error) { return v, nil }
+	keep := func(context.Context, int, int) (bool, error) { return true, nil }
+	noop := func(context.Context, int, int) error { return nil }
+
+	if got, err := PMapCtx(ctx, []int(nil), id); got != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("PMapCtx(canceled, nil) = %v, %v, want nil, %v", got, err, context.Canceled)
+	}
+	if got, err := PFilterCtx(ctx, []int(nil), keep); got != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("PFilterCtx(canceled, nil) = %v, %v, want nil, %v", got, err, context.Canceled)
+	}
+	if err := PForEachCtx(ctx, []int(nil), noop); !errors.Is(err, context.Canceled) {
+		t.Errorf("PForEachCtx(canceled, nil) = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestPFilterCtx(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range parallelOptionsTests {
+		for _, ft := range filterTests {
+			got, err := PFilterCtx(context.Background(), ft.s, func(_ context.Context, i int, v int) (bool, error) {
+				return ft.f(i, v), nil
+			}, tc.opts...)
+			if err != nil || fmt.Sprintf("%#v", ft.want) != fmt.Sprintf("%#v", got) {
+				t.Errorf("%s: PFilterCtx(%#v) = %#v, %v, want %#v, nil", tc.name, ft.s, got, err, ft.want)
+			}
+		}
+
+		s := sliceGenerator(1000)
+		even := func(_ int, v int) bool { return v%2 == 0 }
+		want := Filter(s, even)
+		got, err := PFilterCtx(context.Background(), s, func(_ context.Context, i int, v int) (bool, error) {
+			return even(i, v), nil
+		}, tc.opts...)
+		if err != nil || !Equal(want, got) {
+			t.Errorf("%s: PFilterCtx() = %v, %v, want %v, nil", tc.name, got, err, want)
+		}
+
+		got, err = PFilterCtx(context.Background(), s, func(_ context.Context, i int, _ int) (bool, error) {
+			if i == len(s)-1 {
+				return false, errTest
+			}
+			return true, nil
+		}, tc.opts...)
+		if got != nil || !errors.Is(err, errTest) {
+			t.Errorf("%s: PFilterCtx() = %v, %v, want nil, %v", tc.name, got, err, errTest)
+		}
+	}
+}
+
+func TestPForEachCtx(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range parallelOptionsTests {
+		for _, ft := range pForEachTests {
+			var n atomic.Int64
+			err := PForEachCtx(context.Background(), ft.s, func(context.Context, int, int) error {
+				n.Add(1)
+				return nil
+			}, tc.opts...)
+			if got := int(n.Load()); err != nil || ft.want != got {
+				t.Errorf("%s: PForEachCtx(%#v) = %v, %v, want %v, nil", tc.name, ft.s, got, err, ft.want)
+			}
+		}
+
+		s := make([]int, 1003)
+		var sum atomic.Int64
+		err := PForEachCtx(context.Background(), s, func(_ context.Context, i int, _ int) error {
+			sum.Add(int64(i))
+			return nil
+		}, tc.opts...)
+		if want := int64(len(s) * (len(s) - 1) / 2); err != nil || want != sum.Load() {
+			t.Errorf("%s: PForEachCtx() sum = %v, %v, want %v, nil", tc.name, sum.Load(), err, want)
+		}
+
+		err = PForEachCtx(context.Background(), s, func(ctx context.Context, i int, _ int) error {
+			if i == 0 {
+				return errTest
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		}, tc.opts...)
+		if !errors.Is(err, errTest) {
+			t.Errorf("%s: PForEachCtx() = %v, want %v", tc.name, err, errTest)
+		}
+	}
+}
+
+// Tests for convenience wrappers.
+
+func TestSlice_PFilterCtx(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range filterTests {
+		got, err := NewSlice(tc.s).PFilterCtx(context.Background(), func(_ context.Context, i int, v int) (bool, error) {
+			return tc.f(i, v), nil
+		})
+		if err != nil || fmt.Sprintf("%#v", tc.want) != fmt.Sprintf("%#v", []int(got)) {
+			t.Errorf("%#v.PFilterCtx() = %#v, %v, want %#v, nil", tc.s, got, err, tc.want)
+		}
+	}
+}
+
+func TestSlice_PForEachCtx(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range pForEachTests {
+		var n atomic.Int64
+		err := NewSlice(tc.s).PForEachCtx(context.Background(), func(context.Context, int, int) error {
+			n.Add(1)
+			return nil
+		})
+		if got := int(n.Load()); err != nil || tc.want != got {
+			t.Errorf("%#v.PForEachCtx() = %v, %v, want %v, nil", tc.s, got, err, tc.want)
+		}
+	}
+}
+
+func BenchmarkPMapCtx(b *testing.B) {
+	s := sliceGenerator(1000_000)
+	f := func(_ context.Context, _ int, v int) (string, error) { return strconv.Itoa(v), nil }
+
+	b.Run("default", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = PMapCtx(context.Background(), s, f)
+		}
+	})
+
+	b.Run("chunk1024", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = PMapCtx(context.Background(), s, f, WithChunkSize(1024))
+		}
+	})
+}
+
+func TestPForEach_AllElements(t *testing.T) {
+	t.Parallel()
+
+	// Sizes that are not multiples of the number of goroutines.
+	for _, n := range []int{1, 2, 3, 5, 7, 10, 13, 101, 1001} {
+		s := make([]int, n)
+		PForEach(s, func(i int, _ int) { s[i] = i + 1 })
+		for i, v := range s {
+			if v != i+1 {
+				t.Errorf("PForEach(%d elements) skipped element %d", n, i)
+				break
+			}
+		}
+
+		got := PMap(s, func(_ int, v int) int { return v })
+		if !Equal(s, got) {
+			t.Errorf("PMap(%d elements) = %v, want %v", n, got, s)
+		}
+
+		got = PFilter(s, func(int, int) bool { return true })
+		if len(got) != n {
+			t.Errorf("PFilter(%d elements) returned %d elements", n, len(got))
+		}
+	}
+}
+
+// recoverPanicError calls f and returns the *PanicError it panicked with, if any.
+func recoverPanicError(f func()) (p *PanicError) {
+	defer func() {
+		p, _ = recover().(*PanicError)
+	}()
+
+	f()
+	return nil
+}
+
+func TestPanicPropagation(t *testing.T) {
+	t.Parallel()
+
+	s := sliceGenerator(1000)
+	testcases := []struct {
+		name string
+		f    func()
+	}{
+		{"PMap", func() { PMap(s, func(i int, v int) int { panicAt(i); return v }) }},
+		{"PFilter", func() { PFilter(s, func(i int, _ int) bool { panicAt(i); return true }) }},
+		{"PForEach", func() { PForEach(s, func(i int, _ int) { panicAt(i) }) }},
+		{"PMapCtx", func() {
+			_, _ = PMapCtx(context.Background(), s, func(_ context.Context, i int, v int) (int, error) { panicAt(i); return v, nil })
+		}},
+		{"PFilterCtx", func() {
+			_, _ = PFilterCtx(context.Background(), s, func(_ context.Context, i int, _ int) (bool, error) { panicAt(i); return true, nil })
+		}},
+		{"PForEachCtx", func() {
+			_ = PForEachCtx(context.Background(), s, func(_ context.Context, i int, _ int) error { panicAt(i); return nil })
+		}},
+	}
+
+	for _, tc := range testcases {
+		p := recoverPanicError(tc.f)
+		if p == nil {
+			t.Errorf("%s: got no *PanicError panic, want one", tc.name)
+			continue
+		}
+		if p.Value != "boom" {
+			t.Errorf("%s: PanicError.Value = %v, want %q", tc.name, p.Value, "boom")
+		}
+		if !strings.Contains(string(p.Stack), "panicAt") {
+			t.Errorf("%s: PanicError.Stack does not contain the worker stack:\n%s", tc.name, p.Stack)
+		}
+	}
+}
+
+func panicAt(i int) {
+	if i == 500 {
+		panic("boom")
+	}
+}
+
+func TestPanicError(t *testing.T) {
+	t.Parallel()
+
+	p := recoverPanicError(func() {
+		PForEach([]int{1}, func(int, int) { panic(errTest) })
+	})
+	if p == nil {
+		t.Fatalf("PForEach: got no *PanicError panic, want one")
+	}
+	if !errors.Is(p, errTest) {
+		t.Errorf("errors.Is(%v, errTest) = false, want true", p.Value)
+	}
+	if msg := p.Error(); !strings.HasPrefix(msg, errTest.Error()) {
+		t.Errorf("PanicError.Error() = %q, want prefix %q", msg, errTest.Error())
+	}
+}
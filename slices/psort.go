@@ -0,0 +1,167 @@
+package slices
+
+import (
+	"context"
+
+	"golang.org/x/exp/constraints"
+)
+
+// psortMinLen is the length below which the parallel sorts sort sequentially
+// unless a chunk size is given, since the cost of the goroutines and of the
+// merge buffer outweighs the gain. See BenchmarkPSort.
+const psortMinLen = 1 << 13
+
+// PSort is like Sort but sorts chunks of s concurrently, then merges them
+// concurrently using a temporary buffer of len(s) elements.
+// Like PMap, it uses runtime.NumCPU() goroutines and one chunk per goroutine
+// by default; the concurrency and the chunk size can be chosen through opts.
+// Slices shorter than 8192 elements are sorted sequentially, unless a chunk size is given.
+// For floating-point numbers, NaNs are ordered before other values.
+func PSort[S ~[]E, E constraints.Ordered](s S, opts ...Option) {
+	psort(s, cmpCompare[E], Sort[S, E], opts)
+}
+
+// PSortFunc is like PSort but uses a comparison function, which must be
+// a strict weak ordering as defined by SortFunc.
+// If cmp panics while sorting concurrently, PSortFunc panics with a *PanicError,
+// and s is left in an unspecified order.
+func PSortFunc[S ~[]E, E any](s S, cmp func(a, b E) int, opts ...Option) {
+	psort(s, cmp, func(s S) { SortFunc(s, cmp) }, opts)
+}
+
+// PSortStableFunc is like PSortFunc but keeps the original order of equal elements,
+// as SortStableFunc does.
+func PSortStableFunc[S ~[]E, E any](s S, cmp func(a, b E) int, opts ...Option) {
+	psort(s, cmp, func(s S) { SortStableFunc(s, cmp) }, opts)
+}
+
+// psort sorts the chunks of s using sortChunk, then merges them pairwise, stably,
+// in rounds of doubling width, alternating between s and a buffer.
+func psort[S ~[]E, E any](s S, cmp func(a, b E) int, sortChunk func(S), opts []Option) {
+	n := len(s)
+	o := newOptions(n, opts)
+	if n < psortMinLen && !hasChunkSize(opts) || o.concurrency == 1 || o.chunkSize >= n {
+		sortChunk(s)
+		return
+	}
+
+	ctx := context.Background()
+	_ = forEachChunk(ctx, n, o, func(_ context.Context, start, end int) error {
+		sortChunk(s[start:end])
+		return nil
+	})
+
+	src, dst := s, make(S, n)
+	for width := o.chunkSize; width < n; width *= 2 {
+		// When there are fewer pairs of runs than goroutines,
+		// split every merge into parts that can run concurrently.
+		npairs := (n + 2*width - 1) / (2 * width)
+		parts := o.concurrency / npairs
+		if parts < 1 {
+			parts = 1
+		}
+
+		mo := options{concurrency: o.concurrency, chunkSize: 1}
+		_ = forEachChunk(ctx, npairs*parts, mo, func(_ context.Context, start, end int) error {
+			for t := start; t < end; t++ {
+				lo := t / parts * 2 * width
+				mid, hi := lo+width, lo+2*width
+				if mid > n {
+					mid = n
+				}
+				if hi > n {
+					hi = n
+				}
+
+				a, b, out := src[lo:mid], src[mid:hi], dst[lo:hi]
+				part := t % parts
+				k1, k2 := len(out)*part/parts, len(out)*(part+1)/parts
+				i1, i2 := mergeSplit(a, b, k1, cmp), mergeSplit(a, b, k2, cmp)
+				mergeCmpFunc(out[k1:k2], a[i1:i2], b[k1-i1:k2-i2], cmp)
+			}
+			return nil
+		})
+		src, dst = dst, src
+	}
+
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
+
+// hasChunkSize reports whether opts set the chunk size.
+func hasChunkSize(opts []Option) bool {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o.chunkSize > 0
+}
+
+// mergeSplit returns the number of elements of a among the first k elements
+// of the stable merge of the sorted slices a and b.
+func mergeSplit[E any](a, b []E, k int, cmp func(a, b E) int) int {
+	// Too few elements of a are taken while a[i] is ordered before or equal to
+	// b[k-i-1], since the stable merge takes a[i] first.
+	i, j := 0, k
+	if k > len(b) {
+		i = k - len(b)
+	}
+	if j > len(a) {
+		j = len(a)
+	}
+	for i < j {
+		h := int(uint(i+j) >> 1) // avoid overflow when computing h
+		if cmp(a[h], b[k-h-1]) <= 0 {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
+
+// mergeCmpFunc merges the sorted slices a and b into dst, which must have
+// len(a)+len(b) elements. Equal elements of a are placed before those of b.
+func mergeCmpFunc[E any](dst, a, b []E, cmp func(a, b E) int) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if cmp(b[j], a[i]) < 0 {
+			dst[k] = b[j]
+			j++
+		} else {
+			dst[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
+
+// Convenience wrappers for common cases.
+
+// PSortFunc applies PSortFunc to the receiver, cmp and opts.
+func (s Slice[E]) PSortFunc(cmp func(a, b E) int, opts ...Option) {
+	PSortFunc(s, cmp, opts...)
+}
+
+// PSortStableFunc applies PSortStableFunc to the receiver, cmp and opts.
+func (s Slice[E]) PSortStableFunc(cmp func(a, b E) int, opts ...Option) {
+	PSortStableFunc(s, cmp, opts...)
+}
+
+// PSortFunc applies PSortFunc to the receiver, cmp and opts.
+func (s ComparableSlice[E]) PSortFunc(cmp func(a, b E) int, opts ...Option) {
+	PSortFunc(s, cmp, opts...)
+}
+
+// PSortStableFunc applies PSortStableFunc to the receiver, cmp and opts.
+func (s ComparableSlice[E]) PSortStableFunc(cmp func(a, b E) int, opts ...Option) {
+	PSortStableFunc(s, cmp, opts...)
+}
+
+// PSort applies PSort to the receiver and opts.
+func (s OrderedSlice[E]) PSort(opts ...Option) {
+	PSort(s, opts...)
+}
@@ -0,0 +1,120 @@
+package slices_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+func TestPSort(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{0, 1, 100, 1 << 13, 50_000} {
+		for _, opts := range [][]Option{
+			nil,
+			{WithConcurrency(1)},
+			{WithConcurrency(3)},
+			{WithConcurrency(4), WithChunkSize(1000)},
+			{WithConcurrency(16), WithChunkSize(777)},
+		} {
+			s := sliceGenerator(n)
+			want := Clone(s)
+			Sort(want)
+
+			got := Clone(s)
+			PSort(got, opts...)
+			if !Equal(got, want) {
+				t.Errorf("PSort(%d elements, %d options) is not sorted", n, len(opts))
+			}
+
+			got = Clone(s)
+			PSortFunc(got, func(a, b int) int { return cmp(b, a) }, opts...)
+			if !Equal(got, Reverse(Clone(want))) {
+				t.Errorf("PSortFunc(%d elements, %d options, reversed) is not sorted", n, len(opts))
+			}
+		}
+	}
+}
+
+func TestPSortStableFunc(t *testing.T) {
+	t.Parallel()
+
+	type item struct{ key, seq int }
+	s := make([]item, 100_000)
+	for i := range s {
+		s[i] = item{rand.Intn(100), i}
+	}
+	byKey := func(a, b item) int { return cmp(a.key, b.key) }
+
+	for _, opts := range [][]Option{nil, {WithConcurrency(5), WithChunkSize(3333)}} {
+		got := Clone(s)
+		PSortStableFunc(got, byKey, opts...)
+		for i := 1; i < len(got); i++ {
+			a, b := got[i-1], got[i]
+			if a.key > b.key || a.key == b.key && a.seq > b.seq {
+				t.Fatalf("PSortStableFunc: %v before %v at %d", a, b, i)
+			}
+		}
+	}
+}
+
+func TestPSortFunc_Panic(t *testing.T) {
+	t.Parallel()
+
+	errBoom := errors.New("boom")
+	defer func() {
+		p, ok := recover().(*PanicError)
+		if !ok || !errors.Is(p, errBoom) {
+			t.Errorf("PSortFunc panicked with %v, want a *PanicError wrapping boom", p)
+		}
+	}()
+
+	PSortFunc(sliceGenerator(100_000), func(a, b int) int {
+		if a == b {
+			panic(errBoom)
+		}
+		return cmp(a, b)
+	}, WithConcurrency(4))
+	t.Errorf("PSortFunc did not panic")
+}
+
+// Tests for convenience wrappers.
+
+func TestOrderedSlice_PSort(t *testing.T) {
+	t.Parallel()
+
+	s := NewOrderedSlice(sliceGenerator(20_000))
+	s.PSort(WithConcurrency(4))
+	if !s.IsSorted() {
+		t.Errorf("OrderedSlice.PSort(): not sorted")
+	}
+}
+
+// BenchmarkPSort compares PSort with Sort for increasing sizes, to find the
+// crossover size above which PSort wins on a given machine; psortMinLen should
+// stay above it. The speedup depends on the number of CPUs, see the -cpu flag.
+func BenchmarkPSort(b *testing.B) {
+	for _, n := range []int{1 << 10, 1 << 12, 1 << 13, 1 << 14, 1 << 16, 1 << 20} {
+		s := sliceGenerator(n)
+		c := make([]int, n)
+
+		b.Run(fmt.Sprintf("Sort/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(c, s)
+				Sort(c)
+			}
+		})
+
+		b.Run(fmt.Sprintf("PSort/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(c, s)
+				// Below psortMinLen PSort falls back to Sort unless a chunk size is given,
+				// so give one to measure the parallel path at every size.
+				PSort(c, WithChunkSize((n+7)/8))
+			}
+		})
+	}
+}
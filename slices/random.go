@@ -0,0 +1,201 @@
+package slices
+
+import (
+	"math"
+	"math/rand"
+)
+
+// ShuffleRand is like Shuffle but uses r as the source of randomness,
+// so the result is reproducible for a given seed.
+// If r is nil, the default Source of the math/rand package is used.
+func ShuffleRand[S ~[]E, E any](s S, r *rand.Rand) S {
+	swap := func(i, j int) { s[i], s[j] = s[j], s[i] }
+	if r == nil {
+		rand.Shuffle(len(s), swap)
+	} else {
+		r.Shuffle(len(s), swap)
+	}
+	return s
+}
+
+// Sample returns a new slice of k elements chosen uniformly at random,
+// without replacement, from the slice s, in random order.
+// It uses r as the source of randomness, or the default Source of the math/rand
+// package if r is nil. It runs in O(k) time and space and does not modify s.
+// It returns nil if s is nil, and panics if k < 0 or k > len(s).
+func Sample[S ~[]E, E any](s S, k int, r *rand.Rand) S {
+	if k < 0 || k > len(s) {
+		panic("slices: sample size out of range")
+	}
+
+	// Preserve nil in case it matters.
+	if s == nil {
+		return nil
+	}
+
+	// Partial Fisher-Yates shuffle of the positions, where moved maps
+	// the positions that were swapped to the positions now in their place.
+	n := len(s)
+	moved := make(map[int]int, k)
+	at := func(i int) int {
+		if j, ok := moved[i]; ok {
+			return j
+		}
+		return i
+	}
+
+	sample := make(S, k)
+	for i := range sample {
+		j := i + intn(r, n-i)
+		sample[i] = s[at(j)]
+		moved[j] = at(i)
+	}
+	return sample
+}
+
+// WeightedChoice returns an element of the slice s chosen at random, s[i] being
+// chosen with a probability proportional to weights[i], and reports whether
+// there was an element to choose, that is the weights do not sum to zero.
+// It uses r as the source of randomness, or the default Source of the math/rand
+// package if r is nil. It panics if len(weights) != len(s), or if a weight is
+// negative, infinite or NaN.
+func WeightedChoice[S ~[]E, E any](s S, weights []float64, r *rand.Rand) (E, bool) {
+	if len(weights) != len(s) {
+		panic("slices: weights and elements lengths mismatch")
+	}
+
+	var total, largest float64
+	for _, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			panic("slices: invalid weight")
+		}
+		total += w
+		if w > largest {
+			largest = w
+		}
+	}
+	if total == 0 {
+		return *new(E), false
+	}
+
+	// If the sum overflows, rescale the weights by the largest one,
+	// which keeps their ratios.
+	scale := 1.0
+	if math.IsInf(total, 1) {
+		scale = largest
+		total = 0
+		for _, w := range weights {
+			total += w / scale
+		}
+	}
+
+	var x float64
+	if r == nil {
+		x = rand.Float64() * total
+	} else {
+		x = r.Float64() * total
+	}
+
+	last := 0
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		w /= scale
+		if x < w {
+			return s[i], true
+		}
+		x -= w
+		last = i
+	}
+
+	// Rounding errors may leave x slightly above zero.
+	return s[last], true
+}
+
+// ReservoirSample returns a slice of k elements chosen uniformly at random,
+// without replacement, from the elements yielded by seq, in no particular order.
+// If seq yields fewer than k elements, all of them are returned.
+// It uses r as the source of randomness, or the default Source of the math/rand
+// package if r is nil. It consumes seq in a single pass using O(k) space,
+// so it is suitable for streams of unknown length. It panics if k < 0.
+func ReservoirSample[E any](seq func(yield func(E) bool), k int, r *rand.Rand) []E {
+	if k < 0 {
+		panic("slices: sample size out of range")
+	}
+
+	reservoir := make([]E, 0, k)
+	n := 0
+	seq(func(v E) bool {
+		n++
+		if len(reservoir) < k {
+			reservoir = append(reservoir, v)
+			return true
+		}
+
+		// Keep v with probability k/n, replacing an element chosen uniformly.
+		if j := int63n(r, int64(n)); j < int64(k) {
+			reservoir[j] = v
+		}
+		return true
+	})
+	return reservoir
+}
+
+// ReservoirSampleChan is like ReservoirSample but samples the elements received
+// from the channel c until it is closed.
+func ReservoirSampleChan[E any](c <-chan E, k int, r *rand.Rand) []E {
+	return ReservoirSample(func(yield func(E) bool) {
+		for v := range c {
+			if !yield(v) {
+				return
+			}
+		}
+	}, k, r)
+}
+
+func intn(r *rand.Rand, n int) int {
+	if r == nil {
+		return rand.Intn(n)
+	}
+	return r.Intn(n)
+}
+
+func int63n(r *rand.Rand, n int64) int64 {
+	if r == nil {
+		return rand.Int63n(n)
+	}
+	return r.Int63n(n)
+}
+
+// Convenience wrappers for common cases.
+
+// ShuffleRand returns the result of applying ShuffleRand to the receiver and r.
+func (s Slice[E]) ShuffleRand(r *rand.Rand) Slice[E] {
+	return ShuffleRand(s, r)
+}
+
+// Sample returns the result of applying Sample to the receiver, k and r.
+func (s Slice[E]) Sample(k int, r *rand.Rand) Slice[E] {
+	return Sample(s, k, r)
+}
+
+// WeightedChoice returns the result of applying WeightedChoice to the receiver, weights and r.
+func (s Slice[E]) WeightedChoice(weights []float64, r *rand.Rand) (E, bool) {
+	return WeightedChoice(s, weights, r)
+}
+
+// ShuffleRand returns the result of applying ShuffleRand to the receiver and r.
+func (s ComparableSlice[E]) ShuffleRand(r *rand.Rand) ComparableSlice[E] {
+	return ShuffleRand(s, r)
+}
+
+// Sample returns the result of applying Sample to the receiver, k and r.
+func (s ComparableSlice[E]) Sample(k int, r *rand.Rand) ComparableSlice[E] {
+	return Sample(s, k, r)
+}
+
+// WeightedChoice returns the result of applying WeightedChoice to the receiver, weights and r.
+func (s ComparableSlice[E]) WeightedChoice(weights []float64, r *rand.Rand) (E, bool) {
+	return WeightedChoice(s, weights, r)
+}
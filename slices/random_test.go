@@ -0,0 +1,204 @@
+package slices_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/weiwenchen2022/utils/channels"
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+func TestShuffleRand(t *testing.T) {
+	t.Parallel()
+
+	s1 := ShuffleRand(sequence(100), rand.New(rand.NewSource(1)))
+	s2 := ShuffleRand(sequence(100), rand.New(rand.NewSource(1)))
+	if !Equal(s1, s2) {
+		t.Errorf("ShuffleRand with the same seed: %v != %v", s1, s2)
+	}
+
+	sorted := Clone(s1)
+	Sort(sorted)
+	if !Equal(sorted, sequence(100)) {
+		t.Errorf("ShuffleRand(%v) lost elements: %v", sequence(100), s1)
+	}
+
+	if got := ShuffleRand([]int{1}, nil); !Equal(got, []int{1}) {
+		t.Errorf("ShuffleRand([1], nil) = %v, want [1]", got)
+	}
+}
+
+func TestSample(t *testing.T) {
+	t.Parallel()
+
+	s := sequence(50)
+	for _, k := range []int{0, 1, 10, 50} {
+		got := Sample(s, k, rand.New(rand.NewSource(int64(k))))
+		if len(got) != k {
+			t.Errorf("Sample(%d) has %d elements", k, len(got))
+		}
+
+		again := Sample(s, k, rand.New(rand.NewSource(int64(k))))
+		if !Equal(got, again) {
+			t.Errorf("Sample(%d) with the same seed: %v != %v", k, got, again)
+		}
+
+		seen := make(map[int]bool)
+		for _, v := range got {
+			if seen[v] || v < 0 || v >= len(s) {
+				t.Errorf("Sample(%d) = %v, want distinct elements of s", k, got)
+				break
+			}
+			seen[v] = true
+		}
+	}
+	if !Equal(s, sequence(50)) {
+		t.Errorf("Sample modified the slice: %v", s)
+	}
+
+	if got := Sample([]int(nil), 0, nil); got != nil {
+		t.Errorf("Sample(nil, 0) = %#v, want nil", got)
+	}
+	for _, k := range []int{-1, 51} {
+		if !panics(func() { Sample(s, k, nil) }) {
+			t.Errorf("Sample(%d): got no panic, want panic", k)
+		}
+	}
+
+	// Every element is equally likely to be chosen.
+	r := rand.New(rand.NewSource(2))
+	counts := make([]int, 10)
+	for i := 0; i < 10_000; i++ {
+		for _, v := range Sample(sequence(10), 3, r) {
+			counts[v]++
+		}
+	}
+	for v, c := range counts {
+		if c < 2700 || c > 3300 {
+			t.Errorf("Sample chose %d %d times out of 10000, want about 3000", v, c)
+		}
+	}
+}
+
+func TestWeightedChoice(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(3))
+	s := []string{"a", "b", "c", "d"}
+	weights := []float64{1, 0, 3, 6}
+	counts := make(map[string]int)
+	for i := 0; i < 10_000; i++ {
+		v, ok := WeightedChoice(s, weights, r)
+		if !ok {
+			t.Fatalf("WeightedChoice(%v, %v) = %v, false, want true", s, weights, v)
+		}
+		counts[v]++
+	}
+	if counts["b"] != 0 {
+		t.Errorf("WeightedChoice chose b %d times, want 0", counts["b"])
+	}
+	for i, v := range s {
+		if want := int(weights[i] * 1000); math.Abs(float64(counts[v]-want)) > 300 {
+			t.Errorf("WeightedChoice chose %s %d times out of 10000, want about %d", v, counts[v], want)
+		}
+	}
+
+	// Weights whose sum overflows keep their ratios.
+	weights = []float64{math.MaxFloat64, 0, math.MaxFloat64 / 2, math.MaxFloat64}
+	counts = make(map[string]int)
+	for i := 0; i < 10_000; i++ {
+		v, _ := WeightedChoice(s, weights, r)
+		counts[v]++
+	}
+	for v, want := range map[string]int{"a": 4000, "b": 0, "c": 2000, "d": 4000} {
+		if math.Abs(float64(counts[v]-want)) > 300 {
+			t.Errorf("WeightedChoice(huge weights) chose %s %d times out of 10000, want about %d", v, counts[v], want)
+		}
+	}
+
+	if v, ok := WeightedChoice(s, []float64{0, 0, 0, 0}, r); ok {
+		t.Errorf("WeightedChoice(zero weights) = %v, true, want false", v)
+	}
+	if v, ok := WeightedChoice([]int(nil), nil, nil); ok {
+		t.Errorf("WeightedChoice(nil) = %v, true, want false", v)
+	}
+
+	for _, weights := range [][]float64{{1}, {1, 1, -1, 1}, {1, math.NaN(), 1, 1}, {math.Inf(1), 1, 1, 1}} {
+		if !panics(func() { WeightedChoice(s, weights, r) }) {
+			t.Errorf("WeightedChoice(%v, %v): got no panic, want panic", s, weights)
+		}
+	}
+}
+
+func TestReservoirSample(t *testing.T) {
+	t.Parallel()
+
+	seq := func(n int) func(func(int) bool) {
+		return func(yield func(int) bool) {
+			for i := 0; i < n; i++ {
+				if !yield(i) {
+					return
+				}
+			}
+		}
+	}
+
+	if got := ReservoirSample(seq(3), 5, nil); !Equal(got, []int{0, 1, 2}) {
+		t.Errorf("ReservoirSample(3 elements, 5) = %v, want [0 1 2]", got)
+	}
+	if got := ReservoirSample(seq(3), 0, nil); len(got) != 0 {
+		t.Errorf("ReservoirSample(3 elements, 0) = %v, want []", got)
+	}
+	if !panics(func() { ReservoirSample(seq(3), -1, nil) }) {
+		t.Errorf("ReservoirSample(-1): got no panic, want panic")
+	}
+
+	got := ReservoirSample(seq(1000), 10, rand.New(rand.NewSource(4)))
+	again := ReservoirSample(seq(1000), 10, rand.New(rand.NewSource(4)))
+	if len(got) != 10 || !Equal(got, again) {
+		t.Errorf("ReservoirSample with the same seed: %v != %v", got, again)
+	}
+
+	// Every element is equally likely to be chosen.
+	r := rand.New(rand.NewSource(5))
+	counts := make([]int, 10)
+	for i := 0; i < 10_000; i++ {
+		for _, v := range ReservoirSample(seq(10), 2, r) {
+			counts[v]++
+		}
+	}
+	for v, c := range counts {
+		if c < 1800 || c > 2200 {
+			t.Errorf("ReservoirSample chose %d %d times out of 10000, want about 2000", v, c)
+		}
+	}
+
+	c := channels.SliceToChannel(sequence(100))
+	got = ReservoirSampleChan(c, 5, rand.New(rand.NewSource(6)))
+	if len(got) != 5 {
+		t.Errorf("ReservoirSampleChan(100 elements, 5) = %v, want 5 elements", got)
+	}
+}
+
+// Tests for convenience wrappers.
+
+func TestSlice_Sample(t *testing.T) {
+	t.Parallel()
+
+	s := NewSlice(sequence(20))
+	got := s.Sample(5, rand.New(rand.NewSource(7)))
+	want := Sample(sequence(20), 5, rand.New(rand.NewSource(7)))
+	if !Equal(got, want) {
+		t.Errorf("%v.Sample(5) = %v, want %v", *s, got, want)
+	}
+
+	if v, ok := s.WeightedChoice(Repeat(0.0, 20), nil); ok {
+		t.Errorf("WeightedChoice with zero weights = %v, true, want false", v)
+	}
+}
+
+// sequence returns the slice [0, 1, ..., n-1].
+func sequence(n int) []int {
+	return RepeatFunc(func(i int) int { return i }, n)
+}
@@ -0,0 +1,172 @@
+package slices
+
+// RotateLeft rotates the elements of the slice s in place by k positions
+// to the left: the element at index k moves to index 0.
+// k is taken modulo len(s), and a negative k rotates to the right.
+// It returns the modified slice.
+func RotateLeft[S ~[]E, E any](s S, k int) S {
+	if n := len(s); n > 0 {
+		k %= n
+		if k < 0 {
+			k += n
+		}
+		rotateLeft(s, k)
+	}
+	return s
+}
+
+// RotateRight rotates the elements of the slice s in place by k positions
+// to the right: the element at index 0 moves to index k.
+// k is taken modulo len(s), and a negative k rotates to the left.
+// It returns the modified slice.
+func RotateRight[S ~[]E, E any](s S, k int) S {
+	if n := len(s); n > 0 {
+		k %= n
+		if k > 0 {
+			k -= n
+		}
+		rotateLeft(s, -k)
+	}
+	return s
+}
+
+// Move moves the elements s[i:j] in place, so that they start at index k,
+// keeping the relative order of the other elements. It is equivalent to
+// removing s[i:j] and inserting them back at index k of the rest.
+// It returns the modified slice.
+// Move panics if s[i:j] is not a valid slice of s,
+// or if k is not in the range [0, len(s)-(j-i)].
+func Move[S ~[]E, E any](s S, i, j, k int) S {
+	_ = s[i:j] // bounds check
+	if k < 0 || k > len(s)-(j-i) {
+		panic("slices: Move destination out of range")
+	}
+
+	switch {
+	case k < i:
+		rotateLeft(s[k:j], i-k)
+	case k > i:
+		rotateLeft(s[i:k+(j-i)], j-i)
+	}
+	return s
+}
+
+// SwapRanges swaps the elements s[i:j] and s[k:l] in place, keeping the
+// order of the elements between them. The ranges may have different lengths,
+// in which case the elements between them are shifted.
+// It returns the modified slice.
+// SwapRanges panics if s[i:j] or s[k:l] is not a valid slice of s,
+// or if the ranges overlap.
+func SwapRanges[S ~[]E, E any](s S, i, j, k, l int) S {
+	_, _ = s[i:j], s[k:l] // bounds check
+	if k < i || k == i && l < j {
+		i, j, k, l = k, l, i, j
+	}
+	if j > k {
+		panic("slices: SwapRanges ranges overlap")
+	}
+
+	if j-i == l-k {
+		swapRange(s[i:j], s[k:l])
+		return s
+	}
+
+	// Reversing A M B gives B' M' A', then reversing each part gives B M A.
+	reverse(s[i:l])
+	reverse(s[i : i+l-k])
+	reverse(s[i+l-k : l-(j-i)])
+	reverse(s[l-(j-i) : l])
+	return s
+}
+
+// ReverseRange reverses the elements s[i:j] in place.
+// It returns the modified slice.
+// ReverseRange panics if s[i:j] is not a valid slice of s.
+func ReverseRange[S ~[]E, E any](s S, i, j int) S {
+	reverse(s[i:j])
+	return s
+}
+
+// rotateLeft rotates s in place by r positions to the left, 0 <= r <= len(s),
+// swapping blocks of equal length (the Gries-Mills block-swap algorithm).
+func rotateLeft[E any](s []E, r int) {
+	for r != 0 && r != len(s) {
+		if 2*r <= len(s) {
+			// s = A B C with len(A) == len(C) == r: swap A and C,
+			// C B A, then rotate C B.
+			swapRange(s[:r], s[len(s)-r:])
+			s = s[:len(s)-r]
+		} else {
+			// s = A B C with len(A) == len(C) == len(s)-r: swap A and C,
+			// C B A, then rotate B A.
+			swapRange(s[:len(s)-r], s[r:])
+			s, r = s[len(s)-r:], 2*r-len(s)
+		}
+	}
+}
+
+// swapRange swaps the elements of x and y, which must have the same length.
+func swapRange[E any](x, y []E) {
+	for i := range x {
+		x[i], y[i] = y[i], x[i]
+	}
+}
+
+// reverse reverses the elements of s.
+func reverse[E any](s []E) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Convenience wrappers for common cases.
+
+// RotateLeft returns the result of applying RotateLeft to the receiver and k.
+func (s Slice[E]) RotateLeft(k int) Slice[E] {
+	return RotateLeft(s, k)
+}
+
+// RotateRight returns the result of applying RotateRight to the receiver and k.
+func (s Slice[E]) RotateRight(k int) Slice[E] {
+	return RotateRight(s, k)
+}
+
+// Move returns the result of applying Move to the receiver, i, j and k.
+func (s Slice[E]) Move(i, j, k int) Slice[E] {
+	return Move(s, i, j, k)
+}
+
+// SwapRanges returns the result of applying SwapRanges to the receiver, i, j, k and l.
+func (s Slice[E]) SwapRanges(i, j, k, l int) Slice[E] {
+	return SwapRanges(s, i, j, k, l)
+}
+
+// ReverseRange returns the result of applying ReverseRange to the receiver, i and j.
+func (s Slice[E]) ReverseRange(i, j int) Slice[E] {
+	return ReverseRange(s, i, j)
+}
+
+// RotateLeft returns the result of applying RotateLeft to the receiver and k.
+func (s ComparableSlice[E]) RotateLeft(k int) ComparableSlice[E] {
+	return RotateLeft(s, k)
+}
+
+// RotateRight returns the result of applying RotateRight to the receiver and k.
+func (s ComparableSlice[E]) RotateRight(k int) ComparableSlice[E] {
+	return RotateRight(s, k)
+}
+
+// Move returns the result of applying Move to the receiver, i, j and k.
+func (s ComparableSlice[E]) Move(i, j, k int) ComparableSlice[E] {
+	return Move(s, i, j, k)
+}
+
+// SwapRanges returns the result of applying SwapRanges to the receiver, i, j, k and l.
+func (s ComparableSlice[E]) SwapRanges(i, j, k, l int) ComparableSlice[E] {
+	return SwapRanges(s, i, j, k, l)
+}
+
+// ReverseRange returns the result of applying ReverseRange to the receiver, i and j.
+func (s ComparableSlice[E]) ReverseRange(i, j int) ComparableSlice[E] {
+	return ReverseRange(s, i, j)
+}
@@ -0,0 +1,133 @@
+package slices_test
+
+import (
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+
+	for n := 0; n <= 12; n++ {
+		for k := -2 * n; k <= 2*n; k++ {
+			s := sequence(n)
+			want := s
+			if n > 0 {
+				r := (k%n + n) % n
+				want = Concat(s[r:], s[:r])
+			}
+
+			if got := RotateLeft(Clone(s), k); !Equal(got, want) {
+				t.Errorf("RotateLeft(%v, %d) = %v, want %v", s, k, got, want)
+			}
+			if got := RotateRight(Clone(s), -k); !Equal(got, want) {
+				t.Errorf("RotateRight(%v, %d) = %v, want %v", s, -k, got, want)
+			}
+		}
+	}
+}
+
+func TestMove(t *testing.T) {
+	t.Parallel()
+
+	const n = 8
+	s := sequence(n)
+	for i := 0; i <= n; i++ {
+		for j := i; j <= n; j++ {
+			for k := 0; k <= n-(j-i); k++ {
+				rest := Concat(s[:i:i], s[j:])
+				want := Concat(rest[:k], s[i:j], rest[k:])
+				if got := Move(Clone(s), i, j, k); !Equal(got, want) {
+					t.Errorf("Move(%v, %d, %d, %d) = %v, want %v", s, i, j, k, got, want)
+				}
+			}
+		}
+	}
+
+	for _, k := range []int{-1, 7} {
+		if !panics(func() { Move(sequence(n), 2, 4, k) }) {
+			t.Errorf("Move(%v, 2, 4, %d): got no panic, want panic", s, k)
+		}
+	}
+}
+
+func TestSwapRanges(t *testing.T) {
+	t.Parallel()
+
+	const n = 7
+	s := sequence(n)
+	for i := 0; i <= n; i++ {
+		for j := i; j <= n; j++ {
+			for k := j; k <= n; k++ {
+				for l := k; l <= n; l++ {
+					want := Concat(s[:i], s[k:l], s[j:k], s[i:j], s[l:])
+					if got := SwapRanges(Clone(s), i, j, k, l); !Equal(got, want) {
+						t.Errorf("SwapRanges(%v, %d, %d, %d, %d) = %v, want %v", s, i, j, k, l, got, want)
+					}
+					if got := SwapRanges(Clone(s), k, l, i, j); !Equal(got, want) {
+						t.Errorf("SwapRanges(%v, %d, %d, %d, %d) = %v, want %v", s, k, l, i, j, got, want)
+					}
+				}
+			}
+		}
+	}
+
+	if !panics(func() { SwapRanges(sequence(n), 1, 4, 3, 5) }) {
+		t.Errorf("SwapRanges with overlapping ranges: got no panic, want panic")
+	}
+}
+
+func TestReverseRange(t *testing.T) {
+	t.Parallel()
+
+	s := sequence(6)
+	if got, want := ReverseRange(Clone(s), 1, 5), []int{0, 4, 3, 2, 1, 5}; !Equal(got, want) {
+		t.Errorf("ReverseRange(%v, 1, 5) = %v, want %v", s, got, want)
+	}
+	if got := ReverseRange(Clone(s), 3, 3); !Equal(got, s) {
+		t.Errorf("ReverseRange(%v, 3, 3) = %v, want %v", s, got, s)
+	}
+	if !panics(func() { ReverseRange(s, 4, 2) }) {
+		t.Errorf("ReverseRange(%v, 4, 2): got no panic, want panic", s)
+	}
+}
+
+func TestRotate_Allocs(t *testing.T) {
+	s := sequence(100)
+	allocs := testing.AllocsPerRun(100, func() {
+		RotateLeft(s, 37)
+		RotateRight(s, 11)
+		Move(s, 10, 30, 55)
+		SwapRanges(s, 5, 20, 40, 90)
+		ReverseRange(s, 3, 97)
+	})
+	if allocs != 0 {
+		t.Errorf("in-place range operations allocated %v times, want 0", allocs)
+	}
+}
+
+// Tests for convenience wrappers.
+
+func TestSlice_Rotate(t *testing.T) {
+	t.Parallel()
+
+	s := NewSlice(sequence(5))
+	if got, want := s.RotateLeft(2), []int{2, 3, 4, 0, 1}; !Equal(got, want) {
+		t.Errorf("Slice.RotateLeft(2) = %v, want %v", got, want)
+	}
+	if got, want := s.RotateRight(2), []int{0, 1, 2, 3, 4}; !Equal(got, want) {
+		t.Errorf("Slice.RotateRight(2) = %v, want %v", got, want)
+	}
+	if got, want := s.Move(0, 2, 3), []int{2, 3, 4, 0, 1}; !Equal(got, want) {
+		t.Errorf("Slice.Move(0, 2, 3) = %v, want %v", got, want)
+	}
+
+	c := NewComparableSlice(sequence(5))
+	if got, want := c.SwapRanges(0, 1, 3, 5), []int{3, 4, 1, 2, 0}; !Equal(got, want) {
+		t.Errorf("ComparableSlice.SwapRanges(0, 1, 3, 5) = %v, want %v", got, want)
+	}
+	if got, want := c.ReverseRange(0, 2), []int{4, 3, 1, 2, 0}; !Equal(got, want) {
+		t.Errorf("ComparableSlice.ReverseRange(0, 2) = %v, want %v", got, want)
+	}
+}
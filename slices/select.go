@@ -0,0 +1,310 @@
+package slices
+
+import (
+	"math/bits"
+
+	"golang.org/x/exp/constraints"
+)
+
+// MinFunc returns the minimal element of the slice s, using cmp to compare elements,
+// and reports whether s is non-empty. If there is more than one minimal element
+// according to the cmp function, MinFunc returns the first one.
+func MinFunc[S ~[]E, E any](s S, cmp func(a, b E) int) (E, bool) {
+	i, ok := ArgMinFunc(s, cmp)
+	if !ok {
+		return *new(E), false
+	}
+	return s[i], true
+}
+
+// MaxFunc returns the maximal element of the slice s, using cmp to compare elements,
+// and reports whether s is non-empty. If there is more than one maximal element
+// according to the cmp function, MaxFunc returns the first one.
+func MaxFunc[S ~[]E, E any](s S, cmp func(a, b E) int) (E, bool) {
+	i, ok := ArgMaxFunc(s, cmp)
+	if !ok {
+		return *new(E), false
+	}
+	return s[i], true
+}
+
+// ArgMin returns the index of the first minimal element of the slice s,
+// and reports whether s is non-empty.
+// For floating-point numbers, NaNs are ordered before other values.
+func ArgMin[S ~[]E, E constraints.Ordered](s S) (int, bool) {
+	return ArgMinFunc(s, cmpCompare[E])
+}
+
+// ArgMinFunc is like ArgMin but uses a comparison function.
+func ArgMinFunc[S ~[]E, E any](s S, cmp func(a, b E) int) (int, bool) {
+	if len(s) == 0 {
+		return -1, false
+	}
+
+	m := 0
+	for i := 1; i < len(s); i++ {
+		if cmp(s[i], s[m]) < 0 {
+			m = i
+		}
+	}
+	return m, true
+}
+
+// ArgMax returns the index of the first maximal element of the slice s,
+// and reports whether s is non-empty.
+// For floating-point numbers, NaNs are ordered before other values.
+func ArgMax[S ~[]E, E constraints.Ordered](s S) (int, bool) {
+	return ArgMaxFunc(s, cmpCompare[E])
+}
+
+// ArgMaxFunc is like ArgMax but uses a comparison function.
+func ArgMaxFunc[S ~[]E, E any](s S, cmp func(a, b E) int) (int, bool) {
+	if len(s) == 0 {
+		return -1, false
+	}
+
+	m := 0
+	for i := 1; i < len(s); i++ {
+		if cmp(s[i], s[m]) > 0 {
+			m = i
+		}
+	}
+	return m, true
+}
+
+// MinMax returns the minimal and the maximal elements of the slice s in one pass,
+// and reports whether s is non-empty.
+// For floating-point numbers, NaNs are ordered before other values.
+func MinMax[S ~[]E, E constraints.Ordered](s S) (min, max E, ok bool) {
+	return MinMaxFunc(s, cmpCompare[E])
+}
+
+// MinMaxFunc is like MinMax but uses a comparison function.
+// If there is more than one minimal or maximal element according to the cmp function,
+// MinMaxFunc returns the first ones.
+func MinMaxFunc[S ~[]E, E any](s S, cmp func(a, b E) int) (min, max E, ok bool) {
+	if len(s) == 0 {
+		return min, max, false
+	}
+
+	min, max = s[0], s[0]
+	for _, v := range s[1:] {
+		if cmp(v, min) < 0 {
+			min = v
+		} else if cmp(v, max) > 0 {
+			max = v
+		}
+	}
+	return min, max, true
+}
+
+// NthElement rearranges the slice s so that s[n] is the element that would be
+// in that position if s were sorted, every element before s[n] is less than or
+// equal to it, and every element after s[n] is greater than or equal to it.
+// NthElement modifies the contents of the slice s; it does not create a new slice.
+// It runs in O(len(s)) on average, and panics if n is out of range.
+// For floating-point numbers, NaNs are ordered before other values.
+func NthElement[S ~[]E, E constraints.Ordered](s S, n int) {
+	NthElementFunc(s, n, cmpCompare[E])
+}
+
+// NthElementFunc is like NthElement but uses a comparison function,
+// which must be a strict weak ordering as defined by SortFunc.
+func NthElementFunc[S ~[]E, E any](s S, n int, cmp func(a, b E) int) {
+	_ = s[n] // bounds check
+
+	lo, hi := 0, len(s)
+	limit := 2 * bits.Len(uint(len(s)))
+	for hi-lo > 12 {
+		if limit == 0 {
+			// Too many bad pivots, sort the remaining range instead.
+			pdqsortCmpFunc(s, lo, hi, bits.Len(uint(hi-lo)), cmp)
+			return
+		}
+		limit--
+
+		// Use the median of three as pivot.
+		m := int(uint(lo+hi) >> 1)
+		if cmp(s[m], s[lo]) < 0 {
+			s[m], s[lo] = s[lo], s[m]
+		}
+		if cmp(s[hi-1], s[m]) < 0 {
+			s[hi-1], s[m] = s[m], s[hi-1]
+			if cmp(s[m], s[lo]) < 0 {
+				s[m], s[lo] = s[lo], s[m]
+			}
+		}
+		pivot := s[m]
+
+		// Three-way partition: s[lo:lt] < pivot, s[lt:gt] == pivot, s[gt:hi] > pivot.
+		lt, i, gt := lo, lo, hi
+		for i < gt {
+			switch c := cmp(s[i], pivot); {
+			case c < 0:
+				s[lt], s[i] = s[i], s[lt]
+				lt++
+				i++
+			case c > 0:
+				gt--
+				s[i], s[gt] = s[gt], s[i]
+			default:
+				i++
+			}
+		}
+
+		switch {
+		case n < lt:
+			hi = lt
+		case n >= gt:
+			lo = gt
+		default:
+			return
+		}
+	}
+
+	insertionSortCmpFunc(s, lo, hi, cmp)
+}
+
+// TopK returns a new slice of the k largest elements of the slice s, in descending order.
+// If k > len(s), all the elements are returned. It runs in O(len(s) * log(k))
+// without sorting s, and does not modify s.
+// It returns nil if s is nil.
+// For floating-point numbers, NaNs are ordered before other values.
+func TopK[S ~[]E, E constraints.Ordered](s S, k int) S {
+	return TopKFunc(s, k, cmpCompare[E])
+}
+
+// TopKFunc is like TopK but uses a comparison function.
+func TopKFunc[S ~[]E, E any](s S, k int, cmp func(a, b E) int) S {
+	// Preserve nil in case it matters.
+	if s == nil {
+		return nil
+	}
+
+	if k > len(s) {
+		k = len(s)
+	}
+	if k <= 0 {
+		return S{}
+	}
+
+	// Keep the k largest elements seen so far in a min-heap,
+	// that is a max-heap for the reversed comparison.
+	rev := func(a, b E) int { return cmp(b, a) }
+	h := Clone(s[:k])
+	for i := (k - 1) / 2; i >= 0; i-- {
+		siftDownCmpFunc(h, i, k, 0, rev)
+	}
+
+	for _, v := range s[k:] {
+		if cmp(v, h[0]) > 0 {
+			h[0] = v
+			siftDownCmpFunc(h, 0, k, 0, rev)
+		}
+	}
+
+	// Popping the heap leaves the elements in ascending order for rev,
+	// that is descending order for cmp.
+	for i := k - 1; i > 0; i-- {
+		h[0], h[i] = h[i], h[0]
+		siftDownCmpFunc(h, 0, i, 0, rev)
+	}
+	return h
+}
+
+// Convenience wrappers for common cases.
+
+// MinFunc returns the result of applying MinFunc to the receiver and cmp.
+func (s Slice[E]) MinFunc(cmp func(a, b E) int) (E, bool) {
+	return MinFunc(s, cmp)
+}
+
+// MaxFunc returns the result of applying MaxFunc to the receiver and cmp.
+func (s Slice[E]) MaxFunc(cmp func(a, b E) int) (E, bool) {
+	return MaxFunc(s, cmp)
+}
+
+// ArgMinFunc returns the result of applying ArgMinFunc to the receiver and cmp.
+func (s Slice[E]) ArgMinFunc(cmp func(a, b E) int) (int, bool) {
+	return ArgMinFunc(s, cmp)
+}
+
+// ArgMaxFunc returns the result of applying ArgMaxFunc to the receiver and cmp.
+func (s Slice[E]) ArgMaxFunc(cmp func(a, b E) int) (int, bool) {
+	return ArgMaxFunc(s, cmp)
+}
+
+// MinMaxFunc returns the result of applying MinMaxFunc to the receiver and cmp.
+func (s Slice[E]) MinMaxFunc(cmp func(a, b E) int) (min, max E, ok bool) {
+	return MinMaxFunc(s, cmp)
+}
+
+// NthElementFunc applies NthElementFunc to the receiver, n and cmp.
+func (s Slice[E]) NthElementFunc(n int, cmp func(a, b E) int) {
+	NthElementFunc(s, n, cmp)
+}
+
+// TopKFunc returns the result of applying TopKFunc to the receiver, k and cmp.
+func (s Slice[E]) TopKFunc(k int, cmp func(a, b E) int) Slice[E] {
+	return TopKFunc(s, k, cmp)
+}
+
+// MinFunc returns the result of applying MinFunc to the receiver and cmp.
+func (s ComparableSlice[E]) MinFunc(cmp func(a, b E) int) (E, bool) {
+	return MinFunc(s, cmp)
+}
+
+// MaxFunc returns the result of applying MaxFunc to the receiver and cmp.
+func (s ComparableSlice[E]) MaxFunc(cmp func(a, b E) int) (E, bool) {
+	return MaxFunc(s, cmp)
+}
+
+// ArgMinFunc returns the result of applying ArgMinFunc to the receiver and cmp.
+func (s ComparableSlice[E]) ArgMinFunc(cmp func(a, b E) int) (int, bool) {
+	return ArgMinFunc(s, cmp)
+}
+
+// ArgMaxFunc returns the result of applying ArgMaxFunc to the receiver and cmp.
+func (s ComparableSlice[E]) ArgMaxFunc(cmp func(a, b E) int) (int, bool) {
+	return ArgMaxFunc(s, cmp)
+}
+
+// MinMaxFunc returns the result of applying MinMaxFunc to the receiver and cmp.
+func (s ComparableSlice[E]) MinMaxFunc(cmp func(a, b E) int) (min, max E, ok bool) {
+	return MinMaxFunc(s, cmp)
+}
+
+// NthElementFunc applies NthElementFunc to the receiver, n and cmp.
+func (s ComparableSlice[E]) NthElementFunc(n int, cmp func(a, b E) int) {
+	NthElementFunc(s, n, cmp)
+}
+
+// TopKFunc returns the result of applying TopKFunc to the receiver, k and cmp.
+func (s ComparableSlice[E]) TopKFunc(k int, cmp func(a, b E) int) ComparableSlice[E] {
+	return TopKFunc(s, k, cmp)
+}
+
+// ArgMin returns the result of applying ArgMin to the receiver.
+func (s OrderedSlice[E]) ArgMin() (int, bool) {
+	return ArgMin(s)
+}
+
+// ArgMax returns the result of applying ArgMax to the receiver.
+func (s OrderedSlice[E]) ArgMax() (int, bool) {
+	return ArgMax(s)
+}
+
+// MinMax returns the result of applying MinMax to the receiver.
+func (s OrderedSlice[E]) MinMax() (min, max E, ok bool) {
+	return MinMax(s)
+}
+
+// NthElement applies NthElement to the receiver and n.
+func (s OrderedSlice[E]) NthElement(n int) {
+	NthElement(s, n)
+}
+
+// TopK returns the result of applying TopK to the receiver and k.
+func (s OrderedSlice[E]) TopK(k int) OrderedSlice[E] {
+	return TopK(s, k)
+}
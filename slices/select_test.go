@@ -0,0 +1,220 @@
+package slices_test
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+var minMaxTests = []struct {
+	s                  []int
+	wantMin, wantMax   int
+	wantArgMin, argMax int
+	wantOK             bool
+}{
+	{nil, 0, 0, -1, -1, false},
+	{[]int{}, 0, 0, -1, -1, false},
+	{[]int{7}, 7, 7, 0, 0, true},
+	{[]int{1, 2}, 1, 2, 0, 1, true},
+	{[]int{2, 1}, 1, 2, 1, 0, true},
+	{[]int{2, 1, 3, 1, 3}, 1, 3, 1, 2, true},
+	{[]int{0, 2, -9}, -9, 2, 2, 1, true},
+}
+
+func TestMinMax(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range minMaxTests {
+		if min, max, ok := MinMax(tc.s); min != tc.wantMin || max != tc.wantMax || ok != tc.wantOK {
+			t.Errorf("MinMax(%v) = %v, %v, %t, want %v, %v, %t", tc.s, min, max, ok, tc.wantMin, tc.wantMax, tc.wantOK)
+		}
+		if min, max, ok := MinMaxFunc(tc.s, cmp[int]); min != tc.wantMin || max != tc.wantMax || ok != tc.wantOK {
+			t.Errorf("MinMaxFunc(%v) = %v, %v, %t, want %v, %v, %t", tc.s, min, max, ok, tc.wantMin, tc.wantMax, tc.wantOK)
+		}
+		if min, ok := MinFunc(tc.s, cmp[int]); min != tc.wantMin || ok != tc.wantOK {
+			t.Errorf("MinFunc(%v) = %v, %t, want %v, %t", tc.s, min, ok, tc.wantMin, tc.wantOK)
+		}
+		if max, ok := MaxFunc(tc.s, cmp[int]); max != tc.wantMax || ok != tc.wantOK {
+			t.Errorf("MaxFunc(%v) = %v, %t, want %v, %t", tc.s, max, ok, tc.wantMax, tc.wantOK)
+		}
+		if i, ok := ArgMin(tc.s); i != tc.wantArgMin || ok != tc.wantOK {
+			t.Errorf("ArgMin(%v) = %v, %t, want %v, %t", tc.s, i, ok, tc.wantArgMin, tc.wantOK)
+		}
+		if i, ok := ArgMax(tc.s); i != tc.argMax || ok != tc.wantOK {
+			t.Errorf("ArgMax(%v) = %v, %t, want %v, %t", tc.s, i, ok, tc.argMax, tc.wantOK)
+		}
+	}
+
+	floats := []float64{1, math.NaN(), -1}
+	if i, _ := ArgMin(floats); i != 1 {
+		t.Errorf("ArgMin(%v) = %v, want 1", floats, i)
+	}
+	if i, _ := ArgMax(floats); i != 0 {
+		t.Errorf("ArgMax(%v) = %v, want 0", floats, i)
+	}
+
+	s := []string{"b", "A", "c", "C"}
+	compareLower := func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) }
+	if max, _ := MaxFunc(s, compareLower); max != "c" {
+		t.Errorf("MaxFunc(%v, compareLower) = %v, want c", s, max)
+	}
+	if i, _ := ArgMinFunc(s, compareLower); i != 1 {
+		t.Errorf("ArgMinFunc(%v, compareLower) = %v, want 1", s, i)
+	}
+}
+
+func TestNthElement(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []int{1, 2, 5, 13, 100, 1000} {
+		for _, mod := range []int{3, 1 << 30} {
+			src := make([]int, size)
+			for i := range src {
+				src[i] = rand.Intn(mod)
+			}
+			sorted := Clone(src)
+			Sort(sorted)
+
+			for _, n := range []int{0, size / 3, size / 2, size - 1} {
+				s := Clone(src)
+				NthElement(s, n)
+				if s[n] != sorted[n] {
+					t.Errorf("NthElement(%d elements, %d) = %v, want %v", size, n, s[n], sorted[n])
+				}
+				for i := 0; i < n; i++ {
+					if s[i] > s[n] {
+						t.Errorf("NthElement(%d elements, %d): s[%d] = %v > s[n] = %v", size, n, i, s[i], s[n])
+						break
+					}
+				}
+				for i := n + 1; i < size; i++ {
+					if s[i] < s[n] {
+						t.Errorf("NthElement(%d elements, %d): s[%d] = %v < s[n] = %v", size, n, i, s[i], s[n])
+						break
+					}
+				}
+
+				s = Clone(src)
+				NthElementFunc(s, n, func(a, b int) int { return cmp(b, a) })
+				if s[n] != sorted[size-1-n] {
+					t.Errorf("NthElementFunc(%d elements, %d, reversed) = %v, want %v", size, n, s[n], sorted[size-1-n])
+				}
+			}
+		}
+	}
+
+	if !panics(func() { NthElement([]int{1}, 1) }) {
+		t.Errorf("NthElement out of range: got no panic, want panic")
+	}
+}
+
+var topKTests = []struct {
+	s    []int
+	k    int
+	want []int
+}{
+	{nil, 2, nil},
+	{[]int{}, 2, []int{}},
+	{[]int{1, 2, 3}, 0, []int{}},
+	{[]int{1, 2, 3}, -1, []int{}},
+	{[]int{3, 1, 2}, 1, []int{3}},
+	{[]int{3, 1, 4, 1, 5, 9, 2, 6}, 3, []int{9, 6, 5}},
+	{[]int{3, 1, 2}, 5, []int{3, 2, 1}},
+	{[]int{2, 2, 1, 2}, 2, []int{2, 2}},
+}
+
+func TestTopK(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range topKTests {
+		src := Clone(tc.s)
+		got := TopK(tc.s, tc.k)
+		if (tc.want == nil) != (got == nil) || !Equal(tc.want, got) {
+			t.Errorf("TopK(%v, %d) = %#v, want %#v", tc.s, tc.k, got, tc.want)
+		}
+		if !Equal(src, tc.s) {
+			t.Errorf("TopK(%v, %d) modified the slice: %v", src, tc.k, tc.s)
+		}
+
+		got = TopKFunc(tc.s, tc.k, func(a, b int) int { return cmp(b, a) })
+		want := Reverse(Clone(tc.s))
+		SortFunc(want, cmp[int])
+		if tc.k < len(want) {
+			want = want[:max(tc.k, 0)]
+		}
+		if (tc.s == nil) != (got == nil) || !Equal(want, got) {
+			t.Errorf("TopKFunc(%v, %d, reversed) = %v, want %v", tc.s, tc.k, got, want)
+		}
+	}
+
+	s := sliceGenerator(10_000)
+	sorted := Clone(s)
+	SortFunc(sorted, func(a, b int) int { return cmp(b, a) })
+	if got := TopK(s, 100); !Equal(sorted[:100], got) {
+		t.Errorf("TopK(10000 elements, 100) = %v, want %v", got, sorted[:100])
+	}
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Tests for convenience wrappers.
+
+func TestSlice_MinMaxFunc(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range minMaxTests {
+		s := NewSlice(tc.s)
+		if min, max, ok := s.MinMaxFunc(cmp[int]); min != tc.wantMin || max != tc.wantMax || ok != tc.wantOK {
+			t.Errorf("%v.MinMaxFunc() = %v, %v, %t, want %v, %v, %t", tc.s, min, max, ok, tc.wantMin, tc.wantMax, tc.wantOK)
+		}
+		if i, ok := s.ArgMinFunc(cmp[int]); i != tc.wantArgMin || ok != tc.wantOK {
+			t.Errorf("%v.ArgMinFunc() = %v, %t, want %v, %t", tc.s, i, ok, tc.wantArgMin, tc.wantOK)
+		}
+		if i, ok := s.ArgMaxFunc(cmp[int]); i != tc.argMax || ok != tc.wantOK {
+			t.Errorf("%v.ArgMaxFunc() = %v, %t, want %v, %t", tc.s, i, ok, tc.argMax, tc.wantOK)
+		}
+	}
+}
+
+func TestOrderedSlice_TopK(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range topKTests {
+		got := NewOrderedSlice(tc.s).TopK(tc.k)
+		if (tc.want == nil) != (got == nil) || !Equal(tc.want, got) {
+			t.Errorf("%v.TopK(%d) = %#v, want %#v", tc.s, tc.k, got, tc.want)
+		}
+	}
+
+	s := NewOrderedSlice([]int{5, 1, 4, 2, 3})
+	s.NthElement(2)
+	if (*s)[2] != 3 {
+		t.Errorf("NthElement(2) = %v, want 3", (*s)[2])
+	}
+}
+
+func BenchmarkTopK(b *testing.B) {
+	s := sliceGenerator(1000_000)
+
+	b.Run("TopK", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = TopK(s, 10)
+		}
+	})
+
+	b.Run("Sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c := Clone(s)
+			Sort(c)
+			_ = c[len(c)-10:]
+		}
+	})
+}
@@ -0,0 +1,233 @@
+package slices
+
+import "golang.org/x/exp/constraints"
+
+// The functions below treat sorted slices as sets, or as multisets if they contain
+// duplicates: an element appearing m times in a and n times in b appears max(m, n)
+// times in the union, min(m, n) times in the intersection, max(m-n, 0) times in
+// the difference and |m-n| times in the symmetric difference.
+// Both slices must be sorted in ascending order, by cmp for the Func variants;
+// the result is sorted too. They run in O(len(a)+len(b)) time with a single merge walk,
+// and do not modify a or b.
+
+// Union returns a new sorted slice of the elements of the sorted slices a or b.
+// Elements present in both are taken from a.
+// For floating-point numbers, NaNs are ordered before other values.
+func Union[S ~[]E, E constraints.Ordered](a, b S) S {
+	return UnionFunc(a, b, cmpCompare[E])
+}
+
+// UnionFunc is like Union but uses a comparison function.
+func UnionFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) S {
+	r := make(S, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := cmp(a[i], b[j]); {
+		case c < 0:
+			r = append(r, a[i])
+			i++
+		case c > 0:
+			r = append(r, b[j])
+			j++
+		default:
+			r = append(r, a[i])
+			i++
+			j++
+		}
+	}
+	r = append(r, a[i:]...)
+	return append(r, b[j:]...)
+}
+
+// Intersect returns a new sorted slice of the elements of the sorted slices a and b.
+// The elements are taken from a.
+// For floating-point numbers, NaNs are ordered before other values.
+func Intersect[S ~[]E, E constraints.Ordered](a, b S) S {
+	return IntersectFunc(a, b, cmpCompare[E])
+}
+
+// IntersectFunc is like Intersect but uses a comparison function.
+func IntersectFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) S {
+	r := S{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := cmp(a[i], b[j]); {
+		case c < 0:
+			i++
+		case c > 0:
+			j++
+		default:
+			r = append(r, a[i])
+			i++
+			j++
+		}
+	}
+	return r
+}
+
+// Difference returns a new sorted slice of the elements of the sorted slice a
+// that are not in the sorted slice b.
+// For floating-point numbers, NaNs are ordered before other values.
+func Difference[S ~[]E, E constraints.Ordered](a, b S) S {
+	return DifferenceFunc(a, b, cmpCompare[E])
+}
+
+// DifferenceFunc is like Difference but uses a comparison function.
+func DifferenceFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) S {
+	r := make(S, 0, len(a))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := cmp(a[i], b[j]); {
+		case c < 0:
+			r = append(r, a[i])
+			i++
+		case c > 0:
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return append(r, a[i:]...)
+}
+
+// SymmetricDifference returns a new sorted slice of the elements
+// of either of the sorted slices a and b, but not both.
+// For floating-point numbers, NaNs are ordered before other values.
+func SymmetricDifference[S ~[]E, E constraints.Ordered](a, b S) S {
+	return SymmetricDifferenceFunc(a, b, cmpCompare[E])
+}
+
+// SymmetricDifferenceFunc is like SymmetricDifference but uses a comparison function.
+func SymmetricDifferenceFunc[S ~[]E, E any](a, b S, cmp func(a, b E) int) S {
+	r := S{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := cmp(a[i], b[j]); {
+		case c < 0:
+			r = append(r, a[i])
+			i++
+		case c > 0:
+			r = append(r, b[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	r = append(r, a[i:]...)
+	return append(r, b[j:]...)
+}
+
+// MergeK returns a new sorted slice of all the elements of the sorted slices ss,
+// keeping duplicates. Equal elements keep the order of the slices they come from.
+// It runs in O(n * log(k)) time, where n is the total number of elements and k is len(ss),
+// using a heap of the next element of each slice.
+// For floating-point numbers, NaNs are ordered before other values.
+func MergeK[S ~[]E, E constraints.Ordered](ss []S) S {
+	return MergeKFunc(ss, cmpCompare[E])
+}
+
+// MergeKFunc is like MergeK but uses a comparison function.
+func MergeKFunc[S ~[]E, E any](ss []S, cmp func(a, b E) int) S {
+	// cursor is the position of the next element of slice ss[i].
+	type cursor struct{ i, j int }
+
+	n := 0
+	h := make([]cursor, 0, len(ss))
+	for i, s := range ss {
+		n += len(s)
+		if len(s) > 0 {
+			h = append(h, cursor{i, 0})
+		}
+	}
+
+	// The heap keeps the least cursor on top, so it is a max-heap for the reversed order.
+	rev := func(x, y cursor) int {
+		if c := cmp(ss[y.i][y.j], ss[x.i][x.j]); c != 0 {
+			return c
+		}
+		return y.i - x.i
+	}
+	for i := (len(h) - 1) / 2; i >= 0; i-- {
+		siftDownCmpFunc(h, i, len(h), 0, rev)
+	}
+
+	r := make(S, 0, n)
+	for len(h) > 0 {
+		c := &h[0]
+		r = append(r, ss[c.i][c.j])
+
+		c.j++
+		if c.j == len(ss[c.i]) {
+			h[0] = h[len(h)-1]
+			h = h[:len(h)-1]
+		}
+		siftDownCmpFunc(h, 0, len(h), 0, rev)
+	}
+	return r
+}
+
+// Convenience wrappers for common cases.
+
+// UnionFunc returns the result of applying UnionFunc to the receiver, b and cmp.
+func (s Slice[E]) UnionFunc(b []E, cmp func(a, b E) int) Slice[E] {
+	return UnionFunc(s, b, cmp)
+}
+
+// IntersectFunc returns the result of applying IntersectFunc to the receiver, b and cmp.
+func (s Slice[E]) IntersectFunc(b []E, cmp func(a, b E) int) Slice[E] {
+	return IntersectFunc(s, b, cmp)
+}
+
+// DifferenceFunc returns the result of applying DifferenceFunc to the receiver, b and cmp.
+func (s Slice[E]) DifferenceFunc(b []E, cmp func(a, b E) int) Slice[E] {
+	return DifferenceFunc(s, b, cmp)
+}
+
+// SymmetricDifferenceFunc returns the result of applying SymmetricDifferenceFunc
+// to the receiver, b and cmp.
+func (s Slice[E]) SymmetricDifferenceFunc(b []E, cmp func(a, b E) int) Slice[E] {
+	return SymmetricDifferenceFunc(s, b, cmp)
+}
+
+// UnionFunc returns the result of applying UnionFunc to the receiver, b and cmp.
+func (s ComparableSlice[E]) UnionFunc(b []E, cmp func(a, b E) int) ComparableSlice[E] {
+	return UnionFunc(s, b, cmp)
+}
+
+// IntersectFunc returns the result of applying IntersectFunc to the receiver, b and cmp.
+func (s ComparableSlice[E]) IntersectFunc(b []E, cmp func(a, b E) int) ComparableSlice[E] {
+	return IntersectFunc(s, b, cmp)
+}
+
+// DifferenceFunc returns the result of applying DifferenceFunc to the receiver, b and cmp.
+func (s ComparableSlice[E]) DifferenceFunc(b []E, cmp func(a, b E) int) ComparableSlice[E] {
+	return DifferenceFunc(s, b, cmp)
+}
+
+// SymmetricDifferenceFunc returns the result of applying SymmetricDifferenceFunc
+// to the receiver, b and cmp.
+func (s ComparableSlice[E]) SymmetricDifferenceFunc(b []E, cmp func(a, b E) int) ComparableSlice[E] {
+	return SymmetricDifferenceFunc(s, b, cmp)
+}
+
+// Union returns the result of applying Union to the receiver and b.
+func (s OrderedSlice[E]) Union(b []E) OrderedSlice[E] {
+	return Union(s, b)
+}
+
+// Intersect returns the result of applying Intersect to the receiver and b.
+func (s OrderedSlice[E]) Intersect(b []E) OrderedSlice[E] {
+	return Intersect(s, b)
+}
+
+// Difference returns the result of applying Difference to the receiver and b.
+func (s OrderedSlice[E]) Difference(b []E) OrderedSlice[E] {
+	return Difference(s, b)
+}
+
+// SymmetricDifference returns the result of applying SymmetricDifference to the receiver and b.
+func (s OrderedSlice[E]) SymmetricDifference(b []E) OrderedSlice[E] {
+	return SymmetricDifference(s, b)
+}
@@ -0,0 +1,157 @@
+package slices_test
+
+import (
+	"math/rand"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+var setOpsTests = []struct {
+	a, b                        []int
+	union, intersect, diff, sym []int
+}{
+	{nil, nil, []int{}, []int{}, []int{}, []int{}},
+	{[]int{1, 2}, nil, []int{1, 2}, []int{}, []int{1, 2}, []int{1, 2}},
+	{nil, []int{1, 2}, []int{1, 2}, []int{}, []int{}, []int{1, 2}},
+	{[]int{1, 3, 5, 7}, []int{2, 3, 4, 7, 8}, []int{1, 2, 3, 4, 5, 7, 8}, []int{3, 7}, []int{1, 5}, []int{1, 2, 4, 5, 8}},
+	{[]int{1, 1, 1, 2}, []int{1, 2, 2}, []int{1, 1, 1, 2, 2}, []int{1, 2}, []int{1, 1}, []int{1, 1, 2}},
+}
+
+func TestSetOps(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range setOpsTests {
+		if got := Union(tc.a, tc.b); !Equal(got, tc.union) {
+			t.Errorf("Union(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.union)
+		}
+		if got := Intersect(tc.a, tc.b); !Equal(got, tc.intersect) {
+			t.Errorf("Intersect(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.intersect)
+		}
+		if got := Difference(tc.a, tc.b); !Equal(got, tc.diff) {
+			t.Errorf("Difference(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.diff)
+		}
+		if got := SymmetricDifference(tc.a, tc.b); !Equal(got, tc.sym) {
+			t.Errorf("SymmetricDifference(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.sym)
+		}
+
+		rev := func(x, y int) int { return cmp(y, x) }
+		a, b := Reverse(Clone(tc.a)), Reverse(Clone(tc.b))
+		if got, want := UnionFunc(a, b, rev), Reverse(Clone(tc.union)); !Equal(got, want) {
+			t.Errorf("UnionFunc(%v, %v, reversed) = %v, want %v", a, b, got, want)
+		}
+		if got, want := IntersectFunc(a, b, rev), Reverse(Clone(tc.intersect)); !Equal(got, want) {
+			t.Errorf("IntersectFunc(%v, %v, reversed) = %v, want %v", a, b, got, want)
+		}
+		if got, want := DifferenceFunc(a, b, rev), Reverse(Clone(tc.diff)); !Equal(got, want) {
+			t.Errorf("DifferenceFunc(%v, %v, reversed) = %v, want %v", a, b, got, want)
+		}
+		if got, want := SymmetricDifferenceFunc(a, b, rev), Reverse(Clone(tc.sym)); !Equal(got, want) {
+			t.Errorf("SymmetricDifferenceFunc(%v, %v, reversed) = %v, want %v", a, b, got, want)
+		}
+	}
+}
+
+func TestSetOps_Random(t *testing.T) {
+	t.Parallel()
+
+	counts := func(s []int) map[int]int {
+		m := make(map[int]int)
+		for _, v := range s {
+			m[v]++
+		}
+		return m
+	}
+
+	for i := 0; i < 100; i++ {
+		a, b := make([]int, rand.Intn(20)), make([]int, rand.Intn(20))
+		for i := range a {
+			a[i] = rand.Intn(10)
+		}
+		for i := range b {
+			b[i] = rand.Intn(10)
+		}
+		Sort(a)
+		Sort(b)
+
+		ca, cb := counts(a), counts(b)
+		cu, ci, cd, cs := counts(Union(a, b)), counts(Intersect(a, b)), counts(Difference(a, b)), counts(SymmetricDifference(a, b))
+		for v := 0; v < 10; v++ {
+			m, n := ca[v], cb[v]
+			if cu[v] != Max(m, n) || ci[v] != Min(m, n) || cd[v] != Max(m-n, 0) || cs[v] != Max(m-n, n-m) {
+				t.Fatalf("set operations on %v and %v: wrong count of %d", a, b, v)
+			}
+		}
+		for _, s := range [][]int{Union(a, b), Intersect(a, b), Difference(a, b), SymmetricDifference(a, b)} {
+			if !IsSorted(s) {
+				t.Fatalf("set operations on %v and %v: %v is not sorted", a, b, s)
+			}
+		}
+	}
+}
+
+func TestMergeK(t *testing.T) {
+	t.Parallel()
+
+	if got := MergeK([][]int(nil)); len(got) != 0 {
+		t.Errorf("MergeK(nil) = %v, want []", got)
+	}
+	ss := [][]int{{1, 4, 7}, nil, {2, 5, 8}, {0, 3, 6, 9}, {}}
+	if got, want := MergeK(ss), []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}; !Equal(got, want) {
+		t.Errorf("MergeK(%v) = %v, want %v", ss, got, want)
+	}
+
+	// Equal elements keep the order of their slices.
+	type item struct{ key, src int }
+	items := [][]item{{{1, 0}, {2, 0}}, {{1, 1}, {2, 1}}, {{0, 2}, {1, 2}}}
+	got := MergeKFunc(items, func(a, b item) int { return cmp(a.key, b.key) })
+	want := []item{{0, 2}, {1, 0}, {1, 1}, {1, 2}, {2, 0}, {2, 1}}
+	if !Equal(got, want) {
+		t.Errorf("MergeKFunc(%v) = %v, want %v", items, got, want)
+	}
+
+	var all []int
+	ss = make([][]int, 20)
+	for i := range ss {
+		ss[i] = sliceGenerator(rand.Intn(100))
+		Sort(ss[i])
+		all = append(all, ss[i]...)
+	}
+	Sort(all)
+	if got := MergeK(ss); !Equal(got, all) {
+		t.Errorf("MergeK of 20 random slices = %v, want %v", got, all)
+	}
+}
+
+// Tests for convenience wrappers.
+
+func TestOrderedSlice_SetOps(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range setOpsTests {
+		s := NewOrderedSlice(tc.a)
+		if got := s.Union(tc.b); !Equal(got, tc.union) {
+			t.Errorf("%v.Union(%v) = %v, want %v", tc.a, tc.b, got, tc.union)
+		}
+		if got := s.Intersect(tc.b); !Equal(got, tc.intersect) {
+			t.Errorf("%v.Intersect(%v) = %v, want %v", tc.a, tc.b, got, tc.intersect)
+		}
+		if got := s.Difference(tc.b); !Equal(got, tc.diff) {
+			t.Errorf("%v.Difference(%v) = %v, want %v", tc.a, tc.b, got, tc.diff)
+		}
+		if got := s.SymmetricDifference(tc.b); !Equal(got, tc.sym) {
+			t.Errorf("%v.SymmetricDifference(%v) = %v, want %v", tc.a, tc.b, got, tc.sym)
+		}
+	}
+}
+
+func BenchmarkIntersect(b *testing.B) {
+	x, y := sliceGenerator(100_000), sliceGenerator(100_000)
+	Sort(x)
+	Sort(y)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Intersect(x, y)
+	}
+}
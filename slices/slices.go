@@ -8,7 +8,10 @@ package slices
 
 import (
 	"reflect"
+	"sync"
 
+	"github.com/weiwenchen2022/utils/iterx"
+	"github.com/weiwenchen2022/utils/types"
 	"golang.org/x/exp/constraints"
 )
 
@@ -89,8 +92,7 @@ func (s Slice[E]) Clone() *Slice[E] {
 	return &copy
 }
 
-// DeepClone is like Clone but if elements has an Clone method of the form "Clone() E",
-// it use the result of e.Clone() as assignment right operand.
+// DeepClone is like Clone but the elements are deep copied, see the DeepClone function.
 func (s Slice[E]) DeepClone() *Slice[E] {
 	copy := DeepClone(s)
 	return &copy
@@ -114,6 +116,11 @@ func (s *Slice[E]) Clip() {
 	*s = Clip(*s)
 }
 
+// All returns a lazy iterator over the elements of s, in order.
+func (s Slice[E]) All() iterx.Seq[E] {
+	return iterx.FromSlice(s)
+}
+
 // IsNil reports whether s is nil.
 func (s Slice[E]) IsNil() bool {
 	return s == nil
@@ -247,8 +254,7 @@ func (s ComparableSlice[E]) Clone() *ComparableSlice[E] {
 	return &copy
 }
 
-// DeepClone is like Clone but if elements has an Clone method of the form "Clone() E",
-// it use the result of e.Clone() as assignment right operand.
+// DeepClone is like Clone but the elements are deep copied, see the DeepClone function.
 func (s ComparableSlice[E]) DeepClone() *ComparableSlice[E] {
 	copy := DeepClone(s)
 	return &copy
@@ -264,6 +270,13 @@ func (s *ComparableSlice[E]) Compact() {
 	*s = Compact(*s)
 }
 
+// Uniq removes all duplicate elements, keeping the first occurrence of each
+// element in the original order, and updates the slice s.
+// Unlike Compact, the duplicates need not be adjacent.
+func (s *ComparableSlice[E]) Uniq() {
+	*s = Uniq(*s)
+}
+
 // CompactFunc is like Compact but uses a comparison function.
 func (s *ComparableSlice[E]) CompactFunc(eq func(E, E) bool) {
 	*s = CompactFunc(*s, eq)
@@ -282,6 +295,11 @@ func (s *ComparableSlice[E]) Clip() {
 	*s = Clip(*s)
 }
 
+// All returns a lazy iterator over the elements of s, in order.
+func (s ComparableSlice[E]) All() iterx.Seq[E] {
+	return iterx.FromSlice(s)
+}
+
 // IsNil reports whether s is nil.
 func (s ComparableSlice[E]) IsNil() bool {
 	return s == nil
@@ -485,24 +503,41 @@ func Delete[S ~[]E, E any](s S, i, j int) S {
 
 	s2 := append(s[:i], s[j:]...)
 
-	if containsPointer(*new(E)) {
-		_ = append([]E(s[len(s)-(j-i):]), make([]E, j-i)...)
+	if i < j && containsPointer[E]() {
+		zeroSlice(s[len(s)-(j-i):])
 	}
 
 	return s2
 }
 
-// reports whether a is a pointer or contains pointers.
-func containsPointer(a any) bool {
-	t := reflect.TypeOf(a)
-	if t.Kind() == reflect.Pointer {
-		return true
+// containsPointerCache caches the result of typeContainsPointer per type.
+var containsPointerCache sync.Map // map[reflect.Type]bool
+
+// containsPointer reports whether values of type E are pointers or contain pointers.
+// The result is computed once per type and cached.
+func containsPointer[E any]() bool {
+	t := reflect.TypeOf((*E)(nil)).Elem()
+	if b, ok := containsPointerCache.Load(t); ok {
+		return b.(bool)
 	}
 
-	if t.Kind() == reflect.Struct {
+	b := typeContainsPointer(t)
+	containsPointerCache.Store(t, b)
+	return b
+}
+
+// typeContainsPointer reports whether values of type t are pointers or contain pointers,
+// that is whether they may reference memory the garbage collector must keep alive.
+func typeContainsPointer(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.UnsafePointer,
+		reflect.String, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func, reflect.Interface:
+		return true
+	case reflect.Array:
+		return t.Len() > 0 && typeContainsPointer(t.Elem())
+	case reflect.Struct:
 		for i := 0; i < t.NumField(); i++ {
-			f := reflect.Zero(t.Field(i).Type).Interface()
-			if containsPointer(f) {
+			if typeContainsPointer(t.Field(i).Type) {
 				return true
 			}
 		}
@@ -511,6 +546,14 @@ func containsPointer(a any) bool {
 	return false
 }
 
+// zeroSlice sets all elements of s to the zero value of E.
+func zeroSlice[E any](s []E) {
+	var zero E
+	for i := range s {
+		s[i] = zero
+	}
+}
+
 // Replace replaces the elements s[i:j] by the given v, and returns the
 // modified slice. Replace panics if s[i:j] is not a valid slice of s.
 func Replace[S ~[]E, E any](s S, i, j int, v ...E) S {
@@ -542,7 +585,9 @@ func Clone[S ~[]E, E any](s S) S {
 	return append(S{}, s...)
 }
 
-// DeepClone is like Clone but if elements has an Clone method of the form "Clone() E",
+// DeepClone is like Clone but the elements are deep copied with types.DeepCopy:
+// pointers, slices, maps, arrays and structs they reference are copied recursively.
+// If elements has an Clone method of the form "Clone() E",
 // it use the result of e.Clone() as assignment right operand.
 func DeepClone[S ~[]E, E any](s S) S {
 	// Preserve nil in case it matters.
@@ -550,23 +595,8 @@ func DeepClone[S ~[]E, E any](s S) S {
 		return nil
 	}
 
-	type cloneable interface {
-		Clone() E
-	}
-
-	var v any = *new(E)
-	if _, ok := v.(cloneable); ok {
-		s2 := make(S, len(s))
-
-		var i int
-		for i, v = range s {
-			s2[i] = v.(cloneable).Clone()
-		}
-
-		return s2
-	}
-
-	return Clone(s)
+	// Convert to []E so that a Clone method of S itself is not used.
+	return S(types.DeepCopy([]E(s)))
 }
 
 // Compact replaces consecutive runs of equal elements with a single copy.
@@ -591,8 +621,8 @@ func Compact[S ~[]E, E comparable](s S) S {
 		}
 	}
 
-	if containsPointer(*new(E)) {
-		_ = append(s[i:], make([]E, len(s)-i)...)
+	if i < len(s) && containsPointer[E]() {
+		zeroSlice(s[i:])
 	}
 
 	return s[:i]
@@ -615,8 +645,47 @@ func CompactFunc[S ~[]E, E any](s S, eq func(E, E) bool) S {
 		}
 	}
 
-	if containsPointer(*new(E)) {
-		_ = append(s[i:], make([]E, len(s)-i)...)
+	if i < len(s) && containsPointer[E]() {
+		zeroSlice(s[i:])
+	}
+
+	return s[:i]
+}
+
+// Uniq removes all duplicate elements, keeping the first occurrence of each
+// element in the original order. Unlike Compact, the duplicates need not be adjacent.
+// Uniq modifies the contents of the slice s; it does not create a new slice.
+// When Uniq discards m elements in total, it might not modify the elements
+// s[len(s)-m:len(s)]. If those elements is pointers or contain pointers, Uniq
+// zeroing those elements so that objects they reference can be garbage collected.
+func Uniq[S ~[]E, E comparable](s S) S {
+	return UniqBy(s, func(v E) E { return v })
+}
+
+// UniqBy is like Uniq but two elements are duplicates when key returns the same value for them.
+func UniqBy[S ~[]E, E any, K comparable](s S, key func(E) K) S {
+	if len(s) < 2 {
+		return s
+	}
+
+	seen := make(map[K]struct{}, len(s))
+	i := 0
+	for j := range s {
+		k := key(s[j])
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+
+		if j != i {
+			s[i] = s[j]
+		}
+
+		i++
+	}
+
+	if i < len(s) && containsPointer[E]() {
+		zeroSlice(s[i:])
 	}
 
 	return s[:i]
@@ -5,11 +5,13 @@
 package slices_test
 
 import (
+	"fmt"
 	"math"
 	"strings"
 	"testing"
 
 	. "github.com/weiwenchen2022/utils/slices"
+	"github.com/weiwenchen2022/utils/types"
 
 	"golang.org/x/exp/constraints"
 )
@@ -277,7 +279,7 @@ func TestSlice_CompactFunc(t *testing.T) {
 }
 
 func TestSlice_Grow(t *testing.T) {
-	t.Parallel()
+	// Not parallel: testing.AllocsPerRun panics in parallel tests.
 
 	s1 := NewSlice([]int{1, 2, 3})
 	copy := s1.Clone()
@@ -453,6 +455,18 @@ var equalFloatTests = []struct {
 	},
 }
 
+func TestComparableSlice_Uniq(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range uniqTests {
+		copy := NewComparableSlice(Clone(tc.s))
+		copy.Uniq()
+		if !Equal(tc.want, *copy) {
+			t.Errorf("%v.Uniq() = %v, want %v", tc.s, *copy, tc.want)
+		}
+	}
+}
+
 func TestEqual(t *testing.T) {
 	t.Parallel()
 
@@ -1031,6 +1045,73 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestDeleteClearTail(t *testing.T) {
+	t.Parallel()
+
+	type inner struct {
+		n int
+		m map[int]int
+	}
+
+	type outer struct {
+		a [2]inner
+	}
+
+	s1 := []string{"a", "b", "c"}
+	_ = Delete(s1, 0, 1)
+	if s1[2] != "" {
+		t.Errorf("Delete(%q) did not zero the discarded string", s1)
+	}
+
+	s2 := [][]int{{1}, {2}, {3}}
+	_ = Delete(s2, 0, 2)
+	if s2[1] != nil || s2[2] != nil {
+		t.Errorf("Delete(%v) did not zero the discarded slices", s2)
+	}
+
+	s3 := []any{1, "b", 3}
+	_ = Delete(s3, 1, 2)
+	if s3[2] != nil {
+		t.Errorf("Delete(%v) did not zero the discarded interface", s3)
+	}
+
+	s4 := []outer{{}, {}, {a: [2]inner{{1, nil}, {2, map[int]int{}}}}}
+	_ = Delete(s4, 0, 1)
+	if s4[2].a[1].m != nil {
+		t.Errorf("Delete(%v) did not zero the discarded nested struct", s4)
+	}
+
+	s5 := [][2]*int{{new(int), nil}, {nil, new(int)}}
+	_ = Delete(s5, 0, 1)
+	if s5[1][1] != nil {
+		t.Errorf("Delete(%v) did not zero the discarded array of pointers", s5)
+	}
+
+	s6 := []chan int{make(chan int), make(chan int)}
+	_ = Compact(s6)
+	_ = CompactFunc(s6, func(chan int, chan int) bool { return true })
+	if s6[1] != nil {
+		t.Errorf("CompactFunc(%v) did not zero the discarded channel", s6)
+	}
+
+	// Pointer-free elements are left alone.
+	s7 := [][2]int{{1, 2}, {3, 4}}
+	_ = Delete(s7, 0, 1)
+	if s7[1] != [2]int{3, 4} {
+		t.Errorf("Delete(%v) modified the pointer-free tail", s7)
+	}
+}
+
+func BenchmarkDelete(b *testing.B) {
+	src := make([]string, 64)
+	s := make([]string, len(src))
+
+	for i := 0; i < b.N; i++ {
+		copy(s, src)
+		_ = Delete(s, 0, 1)
+	}
+}
+
 func panics(f func()) (b bool) {
 	defer func() {
 		if x := recover(); x != nil {
@@ -1154,6 +1235,49 @@ func TestDeepClone(t *testing.T) {
 	}
 }
 
+func TestDeepClone_Reflect(t *testing.T) {
+	t.Parallel()
+
+	s1 := []map[string][]int{{"a": {1, 2}}, nil}
+	s2 := DeepClone(s1)
+	if fmt.Sprint(s1) != fmt.Sprint(s2) {
+		t.Errorf("DeepClone(%v) = %v, want %[1]v", s1, s2)
+	}
+	s2[0]["a"][0] = 10
+	if s1[0]["a"][0] != 1 {
+		t.Errorf("DeepClone(%v) shares the maps", s1)
+	}
+
+	type bar struct {
+		p *int
+	}
+	n := 1
+	s3 := []*bar{{&n}, {&n}}
+	s4 := DeepClone(s3)
+	if s4[0] == s3[0] || s4[0].p == &n || *s4[0].p != 1 {
+		t.Errorf("DeepClone(%v) = %v, want a deep copy", s3, s4)
+	}
+	if s4[0].p != s4[1].p {
+		t.Errorf("DeepClone(%v) did not preserve the shared pointer", s3)
+	}
+}
+
+// cloneCounter is a pointer-free type whose Clone method returns a different value.
+type cloneCounter struct{ n int }
+
+func (c cloneCounter) Clone() cloneCounter { return cloneCounter{c.n + 1} }
+
+func TestDeepClone_NestedClone(t *testing.T) {
+	t.Parallel()
+
+	type holder struct{ C cloneCounter }
+	s := []holder{{cloneCounter{1}}, {cloneCounter{2}}}
+	want := types.DeepCopy(s)
+	if got := DeepClone(s); !Equal(got, want) || got[0].C.n != 2 {
+		t.Errorf("DeepClone(%v) = %v, want %v", s, got, want)
+	}
+}
+
 var compactTests = []struct {
 	name string
 	s    []int
@@ -1289,9 +1413,110 @@ func BenchmarkCompactFunc_Large(b *testing.B) {
 	})
 }
 
-func TestGrow(t *testing.T) {
+func BenchmarkDeepClone(b *testing.B) {
+	ints := sliceGenerator(100_000)
+	ptrs := make([]*int, len(ints))
+	for i := range ptrs {
+		ptrs[i] = &ints[i]
+	}
+
+	b.Run("Clone/int", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Clone(ints)
+		}
+	})
+
+	b.Run("DeepClone/int", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = DeepClone(ints)
+		}
+	})
+
+	b.Run("DeepClone/pointer", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = DeepClone(ptrs)
+		}
+	})
+}
+
+var uniqTests = []struct {
+	name string
+	s    []int
+	want []int
+}{
+	{"nil", nil, nil},
+	{"one", []int{1}, []int{1}},
+	{"sorted", []int{1, 2, 3}, []int{1, 2, 3}},
+	{"adjacent", []int{1, 1, 2}, []int{1, 2}},
+	{"unsorted", []int{1, 2, 1}, []int{1, 2}},
+	{"many", []int{3, 1, 3, 2, 1, 4, 2}, []int{3, 1, 2, 4}},
+}
+
+func TestUniq(t *testing.T) {
 	t.Parallel()
 
+	for _, tc := range uniqTests {
+		copy := Clone(tc.s)
+		if got := Uniq(copy); !Equal(tc.want, got) {
+			t.Errorf("Uniq(%v) = %v, want %v", tc.s, got, tc.want)
+		}
+	}
+
+	s := []*int{new(int), new(int), nil, nil}
+	s[1] = s[0]
+	got := Uniq(s)
+	if len(got) != 2 || got[0] != s[0] || got[1] != nil {
+		t.Errorf("Uniq(%v) = %v", s, got)
+	}
+	if s[2] != nil || s[3] != nil {
+		t.Errorf("Uniq did not zero the discarded tail: %v", s)
+	}
+}
+
+func TestUniqBy(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range uniqTests {
+		copy := Clone(tc.s)
+		if got := UniqBy(copy, func(v int) int { return v }); !Equal(tc.want, got) {
+			t.Errorf("UniqBy(%v, identity) = %v, want %v", tc.s, got, tc.want)
+		}
+	}
+
+	s1 := []string{"a", "B", "A", "b", "c"}
+	copy := Clone(s1)
+	want := []string{"a", "B", "c"}
+	if got := UniqBy(copy, strings.ToLower); !Equal(want, got) {
+		t.Errorf("UniqBy(%v, strings.ToLower) = %v, want %v", s1, got, want)
+	}
+}
+
+func BenchmarkUniq(b *testing.B) {
+	src := make([]int, 10_000)
+	for i := range src {
+		src[i] = i % 100
+	}
+	s := make([]int, len(src))
+
+	b.Run("Uniq", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(s, src)
+			_ = Uniq(s)
+		}
+	})
+
+	b.Run("SortCompact", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(s, src)
+			Sort(s)
+			_ = Compact(s)
+		}
+	})
+}
+
+func TestGrow(t *testing.T) {
+	// Not parallel: testing.AllocsPerRun panics in parallel tests.
+
 	s1 := []int{1, 2, 3}
 
 	copy := Clone(s1)
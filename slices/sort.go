@@ -0,0 +1,248 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slices
+
+import (
+	"math/bits"
+
+	"golang.org/x/exp/constraints"
+)
+
+// OrderedSlice is like ComparableSlice but element type requires constraints.Ordered.
+type OrderedSlice[E constraints.Ordered] []E
+
+// NewOrderedSlice creates and initializes a new OrderedSlice using s as its initial
+// contents. The new OrderedSlice takes ownership of s, and the caller should not
+// use s after this call.
+func NewOrderedSlice[E constraints.Ordered](s []E) *OrderedSlice[E] {
+	ss := OrderedSlice[E](s)
+	return &ss
+}
+
+// Sort sorts a slice of any ordered type in ascending order.
+// When sorting floating-point numbers, NaNs are ordered before other values.
+func Sort[S ~[]E, E constraints.Ordered](x S) {
+	n := len(x)
+	pdqsortOrdered(x, 0, n, bits.Len(uint(n)))
+}
+
+// SortFunc sorts the slice x in ascending order as determined by the cmp
+// function. This sort is not guaranteed to be stable.
+// cmp(a, b) should return a negative number when a < b, a positive number when
+// a > b and zero when a == b.
+//
+// SortFunc requires that cmp is a strict weak ordering.
+// See https://en.wikipedia.org/wiki/Weak_ordering#Strict_weak_orderings.
+func SortFunc[S ~[]E, E any](x S, cmp func(a, b E) int) {
+	n := len(x)
+	pdqsortCmpFunc(x, 0, n, bits.Len(uint(n)), cmp)
+}
+
+// SortStableFunc sorts the slice x while keeping the original order of equal
+// elements, using cmp to compare elements in the same way as SortFunc.
+func SortStableFunc[S ~[]E, E any](x S, cmp func(a, b E) int) {
+	stableCmpFunc(x, len(x), cmp)
+}
+
+// IsSorted reports whether x is sorted in ascending order.
+func IsSorted[S ~[]E, E constraints.Ordered](x S) bool {
+	for i := len(x) - 1; i > 0; i-- {
+		if cmpLess(x[i], x[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSortedFunc reports whether x is sorted in ascending order, with cmp as the
+// comparison function as defined by SortFunc.
+func IsSortedFunc[S ~[]E, E any](x S, cmp func(a, b E) int) bool {
+	for i := len(x) - 1; i > 0; i-- {
+		if cmp(x[i], x[i-1]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// BinarySearch searches for target in a sorted slice and returns the earliest
+// position where target is found, or the position where target would appear
+// in the sort order; it also returns a bool saying whether the target is
+// really found in the slice. The slice must be sorted in increasing order.
+func BinarySearch[S ~[]E, E constraints.Ordered](x S, target E) (int, bool) {
+	// Inlining is faster than calling BinarySearchFunc with a lambda.
+	n := len(x)
+	// Define x[-1] < target and x[n] >= target.
+	// Invariant: x[i-1] < target, x[j] >= target.
+	i, j := 0, n
+	for i < j {
+		h := int(uint(i+j) >> 1) // avoid overflow when computing h
+		// i ≤ h < j
+		if cmpLess(x[h], target) {
+			i = h + 1 // preserves x[i-1] < target
+		} else {
+			j = h // preserves x[j] >= target
+		}
+	}
+	// i == j, x[i-1] < target, and x[j] (= x[i]) >= target  =>  answer is i.
+	return i, i < n && (x[i] == target || (isNaN(x[i]) && isNaN(target)))
+}
+
+// BinarySearchFunc works like BinarySearch, but uses a custom comparison
+// function. The slice must be sorted in increasing order, where "increasing"
+// is defined by cmp. cmp should return 0 if the slice element matches
+// the target, a negative number if the slice element precedes the target,
+// or a positive number if the slice element follows the target.
+// cmp must implement the same ordering as the slice, such that if
+// cmp(a, t) < 0 and cmp(b, t) >= 0, then a must precede b in the slice.
+func BinarySearchFunc[S ~[]E, E, T any](x S, target T, cmp func(E, T) int) (int, bool) {
+	n := len(x)
+	// Define cmp(x[-1], target) < 0 and cmp(x[n], target) >= 0 .
+	// Invariant: cmp(x[i - 1], target) < 0, cmp(x[j], target) >= 0.
+	i, j := 0, n
+	for i < j {
+		h := int(uint(i+j) >> 1) // avoid overflow when computing h
+		// i ≤ h < j
+		if cmp(x[h], target) < 0 {
+			i = h + 1 // preserves cmp(x[i - 1], target) < 0
+		} else {
+			j = h // preserves cmp(x[j], target) >= 0
+		}
+	}
+	// i == j, cmp(x[i-1], target) < 0, and cmp(x[j], target) (= cmp(x[i], target)) >= 0  =>  answer is i.
+	return i, i < n && cmp(x[i], target) == 0
+}
+
+type sortedHint int // hint for pdqsort when choosing the pivot
+
+const (
+	unknownHint sortedHint = iota
+	increasingHint
+	decreasingHint
+)
+
+// xorshift paper: https://www.jstatsoft.org/article/view/v008i14/xorshift.pdf
+type xorshift uint64
+
+func (r *xorshift) Next() uint64 {
+	*r ^= *r << 13
+	*r ^= *r >> 7
+	*r ^= *r << 17
+	return uint64(*r)
+}
+
+func nextPowerOfTwo(length int) uint {
+	return 1 << bits.Len(uint(length))
+}
+
+// cmpLess reports whether x is less than y.
+// For floating-point types, a NaN is considered less than any non-NaN.
+func cmpLess[T constraints.Ordered](x, y T) bool {
+	return (isNaN(x) && !isNaN(y)) || x < y
+}
+
+// cmpCompare returns -1 if x is less than y, 0 if x equals y, and +1 if x is greater than y.
+// For floating-point types, a NaN is considered less than any non-NaN
+// and equal to any other NaN, as in cmpLess.
+func cmpCompare[T constraints.Ordered](x, y T) int {
+	xNaN, yNaN := isNaN(x), isNaN(y)
+	switch {
+	case xNaN && yNaN:
+		return 0
+	case xNaN || x < y:
+		return -1
+	case yNaN || x > y:
+		return +1
+	}
+	return 0
+}
+
+// isNaN reports whether x is a NaN without requiring the math package.
+// This will always return false if T is not floating-point.
+func isNaN[T constraints.Ordered](x T) bool {
+	return x != x
+}
+
+// Convenience wrappers for common cases.
+
+// SortFunc applies SortFunc to the receiver and cmp.
+func (s Slice[E]) SortFunc(cmp func(a, b E) int) {
+	SortFunc(s, cmp)
+}
+
+// SortStableFunc applies SortStableFunc to the receiver and cmp.
+func (s Slice[E]) SortStableFunc(cmp func(a, b E) int) {
+	SortStableFunc(s, cmp)
+}
+
+// IsSortedFunc returns the result of applying IsSortedFunc to the receiver and cmp.
+func (s Slice[E]) IsSortedFunc(cmp func(a, b E) int) bool {
+	return IsSortedFunc(s, cmp)
+}
+
+// BinarySearchFunc returns the result of applying BinarySearchFunc to the receiver, target and cmp.
+func (s Slice[E]) BinarySearchFunc(target E, cmp func(E, E) int) (int, bool) {
+	return BinarySearchFunc(s, target, cmp)
+}
+
+// SortFunc applies SortFunc to the receiver and cmp.
+func (s ComparableSlice[E]) SortFunc(cmp func(a, b E) int) {
+	SortFunc(s, cmp)
+}
+
+// SortStableFunc applies SortStableFunc to the receiver and cmp.
+func (s ComparableSlice[E]) SortStableFunc(cmp func(a, b E) int) {
+	SortStableFunc(s, cmp)
+}
+
+// IsSortedFunc returns the result of applying IsSortedFunc to the receiver and cmp.
+func (s ComparableSlice[E]) IsSortedFunc(cmp func(a, b E) int) bool {
+	return IsSortedFunc(s, cmp)
+}
+
+// BinarySearchFunc returns the result of applying BinarySearchFunc to the receiver, target and cmp.
+func (s ComparableSlice[E]) BinarySearchFunc(target E, cmp func(E, E) int) (int, bool) {
+	return BinarySearchFunc(s, target, cmp)
+}
+
+// Compare returns the result of applying Compare to the receiver and t.
+func (s OrderedSlice[E]) Compare(t []E) int {
+	return Compare(s, t)
+}
+
+// Sort applies Sort to the receiver.
+func (s OrderedSlice[E]) Sort() {
+	Sort(s)
+}
+
+// SortFunc applies SortFunc to the receiver and cmp.
+func (s OrderedSlice[E]) SortFunc(cmp func(a, b E) int) {
+	SortFunc(s, cmp)
+}
+
+// SortStableFunc applies SortStableFunc to the receiver and cmp.
+func (s OrderedSlice[E]) SortStableFunc(cmp func(a, b E) int) {
+	SortStableFunc(s, cmp)
+}
+
+// IsSorted returns the result of applying IsSorted to the receiver.
+func (s OrderedSlice[E]) IsSorted() bool {
+	return IsSorted(s)
+}
+
+// IsSortedFunc returns the result of applying IsSortedFunc to the receiver and cmp.
+func (s OrderedSlice[E]) IsSortedFunc(cmp func(a, b E) int) bool {
+	return IsSortedFunc(s, cmp)
+}
+
+// BinarySearch returns the result of applying BinarySearch to the receiver and target.
+func (s OrderedSlice[E]) BinarySearch(target E) (int, bool) {
+	return BinarySearch(s, target)
+}
+
+// BinarySearchFunc returns the result of applying BinarySearchFunc to the receiver, target and cmp.
+func (s OrderedSlice[E]) BinarySearchFunc(target E, cmp func(E, E) int) (int, bool) {
+	return BinarySearchFunc(s, target, cmp)
+}
@@ -0,0 +1,365 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package slices_test
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+var ints = [...]int{74, 59, 238, -784, 9845, 959, 905, 0, 0, 42, 7586, -5467984, 7586}
+var float64s = [...]float64{74.3, 59.0, math.Inf(1), 238.2, -784.0, 2.3, math.NaN(), math.NaN(), math.Inf(-1), 9845.768, -959.7485, 905, 7.8, 7.8}
+var strs = [...]string{"", "Hello", "foo", "bar", "foo", "f00", "%*&^*&^&", "***"}
+
+func TestSort(t *testing.T) {
+	t.Parallel()
+
+	data := Clone(ints[:])
+	Sort(data)
+	if !IsSorted(data) {
+		t.Errorf("Sort(%v) = %v", ints, data)
+	}
+
+	floats := Clone(float64s[:])
+	Sort(floats)
+	if !IsSorted(floats) {
+		t.Errorf("Sort(%v) = %v", float64s, floats)
+	}
+	if !math.IsNaN(floats[0]) || !math.IsNaN(floats[1]) {
+		t.Errorf("Sort(%v) = %v, want NaNs first", float64s, floats)
+	}
+
+	strings := Clone(strs[:])
+	Sort(strings)
+	if !IsSorted(strings) {
+		t.Errorf("Sort(%v) = %v", strs, strings)
+	}
+}
+
+func TestSortFunc(t *testing.T) {
+	t.Parallel()
+
+	data := Clone(ints[:])
+	SortFunc(data, cmp[int])
+	if !IsSorted(data) {
+		t.Errorf("SortFunc(%v, cmp[int]) = %v", ints, data)
+	}
+
+	reversed := Clone(ints[:])
+	SortFunc(reversed, func(a, b int) int { return cmp(b, a) })
+	if !IsSortedFunc(reversed, func(a, b int) int { return cmp(b, a) }) {
+		t.Errorf("SortFunc(%v, reverse) = %v", ints, reversed)
+	}
+
+	folded := []string{"b", "A", "c", "B", "a"}
+	SortFunc(folded, func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+	if got := strings.ToLower(strings.Join(folded, "")); got != "aabbc" {
+		t.Errorf("SortFunc(compareLower) = %v", folded)
+	}
+}
+
+func TestSortLarge_Random(t *testing.T) {
+	t.Parallel()
+
+	n := 1000000
+	if testing.Short() {
+		n /= 100
+	}
+
+	data := make([]int, n)
+	for i := range data {
+		data[i] = rand.Intn(100)
+	}
+	if IsSorted(data) {
+		t.Fatalf("terrible rand.rand")
+	}
+
+	Sort(data)
+	if !IsSorted(data) {
+		t.Errorf("Sort didn't sort %d ints", n)
+	}
+}
+
+type intPair struct {
+	a, b int
+}
+
+type intPairs []intPair
+
+// Pairs compare on a only.
+func intPairCmp(x, y intPair) int {
+	return x.a - y.a
+}
+
+// Record initial order in b.
+func (d intPairs) initB() {
+	for i := range d {
+		d[i].b = i
+	}
+}
+
+// inOrder checks if a-equal elements were not reordered.
+func (d intPairs) inOrder() bool {
+	lastA, lastB := -1, 0
+	for i := 0; i < len(d); i++ {
+		if lastA != d[i].a {
+			lastA = d[i].a
+			lastB = d[i].b
+			continue
+		}
+
+		if d[i].b <= lastB {
+			return false
+		}
+		lastB = d[i].b
+	}
+	return true
+}
+
+func TestSortStableFunc(t *testing.T) {
+	t.Parallel()
+
+	n, m := 100000, 1000
+	if testing.Short() {
+		n, m = 1000, 100
+	}
+	data := make(intPairs, n)
+
+	// random distribution
+	for i := range data {
+		data[i].a = rand.Intn(m)
+	}
+	if IsSortedFunc(data, intPairCmp) {
+		t.Fatalf("terrible rand.rand")
+	}
+	data.initB()
+	SortStableFunc(data, intPairCmp)
+	if !IsSortedFunc(data, intPairCmp) {
+		t.Errorf("SortStableFunc didn't sort %d ints", n)
+	}
+	if !data.inOrder() {
+		t.Errorf("SortStableFunc wasn't stable on %d ints", n)
+	}
+
+	// already sorted
+	data.initB()
+	SortStableFunc(data, intPairCmp)
+	if !IsSortedFunc(data, intPairCmp) {
+		t.Errorf("SortStableFunc shuffled sorted %d ints (order)", n)
+	}
+	if !data.inOrder() {
+		t.Errorf("SortStableFunc shuffled sorted %d ints (stability)", n)
+	}
+
+	// sorted reversed
+	for i := range data {
+		data[i].a = len(data) - i
+	}
+	data.initB()
+	SortStableFunc(data, intPairCmp)
+	if !IsSortedFunc(data, intPairCmp) {
+		t.Errorf("SortStableFunc didn't sort %d ints", n)
+	}
+	if !data.inOrder() {
+		t.Errorf("SortStableFunc wasn't stable on %d ints", n)
+	}
+}
+
+var isSortedTests = []struct {
+	s    []int
+	want bool
+}{
+	{nil, true},
+	{[]int{1}, true},
+	{[]int{1, 1, 2}, true},
+	{[]int{2, 1}, false},
+	{[]int{1, 3, 2}, false},
+}
+
+func TestIsSorted(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range isSortedTests {
+		if got := IsSorted(tc.s); tc.want != got {
+			t.Errorf("IsSorted(%v) = %t, want %t", tc.s, got, tc.want)
+		}
+		if got := IsSortedFunc(tc.s, cmp[int]); tc.want != got {
+			t.Errorf("IsSortedFunc(%v, cmp[int]) = %t, want %t", tc.s, got, tc.want)
+		}
+	}
+}
+
+var binarySearchTests = []struct {
+	data      []string
+	target    string
+	wantPos   int
+	wantFound bool
+}{
+	{[]string{}, "foo", 0, false},
+	{[]string{}, "", 0, false},
+
+	{[]string{"a"}, "", 0, false},
+	{[]string{"a"}, "a", 0, true},
+	{[]string{"a"}, "b", 1, false},
+
+	{[]string{"a", "b"}, "", 0, false},
+	{[]string{"a", "b"}, "a", 0, true},
+	{[]string{"a", "b"}, "aa", 1, false},
+	{[]string{"a", "b"}, "b", 1, true},
+	{[]string{"a", "b"}, "c", 2, false},
+
+	{[]string{"a", "b", "c"}, "", 0, false},
+	{[]string{"a", "b", "c"}, "a", 0, true},
+	{[]string{"a", "b", "c"}, "aa", 1, false},
+	{[]string{"a", "b", "c"}, "b", 1, true},
+	{[]string{"a", "b", "c"}, "bb", 2, false},
+	{[]string{"a", "b", "c"}, "c", 2, true},
+	{[]string{"a", "b", "c"}, "cc", 3, false},
+
+	{[]string{"a", "a", "b", "c"}, "a", 0, true},
+	{[]string{"a", "b", "b", "c"}, "b", 1, true},
+}
+
+func TestBinarySearch(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range binarySearchTests {
+		pos, found := BinarySearch(tc.data, tc.target)
+		if tc.wantPos != pos || tc.wantFound != found {
+			t.Errorf("BinarySearch(%v, %q) = %v, %v, want %v, %v", tc.data, tc.target, pos, found, tc.wantPos, tc.wantFound)
+		}
+
+		pos, found = BinarySearchFunc(tc.data, tc.target, strings.Compare)
+		if tc.wantPos != pos || tc.wantFound != found {
+			t.Errorf("BinarySearchFunc(%v, %q, strings.Compare) = %v, %v, want %v, %v", tc.data, tc.target, pos, found, tc.wantPos, tc.wantFound)
+		}
+	}
+
+	floats := []float64{math.NaN(), math.Inf(-1), -1, 0, 1, math.Inf(1)}
+	if pos, found := BinarySearch(floats, math.NaN()); pos != 0 || !found {
+		t.Errorf("BinarySearch(%v, NaN) = %v, %v, want 0, true", floats, pos, found)
+	}
+	if pos, found := BinarySearch(floats, 0.5); pos != 4 || found {
+		t.Errorf("BinarySearch(%v, 0.5) = %v, %v, want 4, false", floats, pos, found)
+	}
+}
+
+func TestBinarySearchFunc(t *testing.T) {
+	t.Parallel()
+
+	data := []intPair{{1, 10}, {3, 30}, {5, 50}}
+	cmpA := func(p intPair, a int) int { return p.a - a }
+
+	if pos, found := BinarySearchFunc(data, 3, cmpA); pos != 1 || !found {
+		t.Errorf("BinarySearchFunc(%v, 3) = %v, %v, want 1, true", data, pos, found)
+	}
+	if pos, found := BinarySearchFunc(data, 4, cmpA); pos != 2 || found {
+		t.Errorf("BinarySearchFunc(%v, 4) = %v, %v, want 2, false", data, pos, found)
+	}
+}
+
+// Tests for convenience wrappers.
+
+func TestSlice_SortFunc(t *testing.T) {
+	t.Parallel()
+
+	s := NewSlice(Clone(ints[:]))
+	s.SortFunc(cmp[int])
+	if !s.IsSortedFunc(cmp[int]) {
+		t.Errorf("%v.SortFunc(cmp[int]) = %v", ints, *s)
+	}
+
+	data := make(intPairs, 1000)
+	for i := range data {
+		data[i].a = rand.Intn(10)
+	}
+	data.initB()
+	NewSlice(data).SortStableFunc(intPairCmp)
+	if !data.inOrder() {
+		t.Errorf("SortStableFunc wasn't stable on %d ints", len(data))
+	}
+}
+
+func TestSlice_BinarySearchFunc(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range binarySearchTests {
+		pos, found := NewSlice(tc.data).BinarySearchFunc(tc.target, strings.Compare)
+		if tc.wantPos != pos || tc.wantFound != found {
+			t.Errorf("%v.BinarySearchFunc(%q, strings.Compare) = %v, %v, want %v, %v", tc.data, tc.target, pos, found, tc.wantPos, tc.wantFound)
+		}
+	}
+}
+
+func TestComparableSlice_SortFunc(t *testing.T) {
+	t.Parallel()
+
+	s := NewComparableSlice(Clone(strs[:]))
+	s.SortFunc(strings.Compare)
+	if !s.IsSortedFunc(strings.Compare) {
+		t.Errorf("%v.SortFunc(strings.Compare) = %v", strs, *s)
+	}
+
+	for _, tc := range binarySearchTests {
+		pos, found := NewComparableSlice(tc.data).BinarySearchFunc(tc.target, strings.Compare)
+		if tc.wantPos != pos || tc.wantFound != found {
+			t.Errorf("%v.BinarySearchFunc(%q, strings.Compare) = %v, %v, want %v, %v", tc.data, tc.target, pos, found, tc.wantPos, tc.wantFound)
+		}
+	}
+}
+
+func TestOrderedSlice_Sort(t *testing.T) {
+	t.Parallel()
+
+	s := NewOrderedSlice(Clone(ints[:]))
+	if s.IsSorted() {
+		t.Fatalf("%v.IsSorted() = true, want false", ints)
+	}
+	s.Sort()
+	if !s.IsSorted() {
+		t.Errorf("%v.Sort() = %v", ints, *s)
+	}
+
+	for _, tc := range binarySearchTests {
+		pos, found := NewOrderedSlice(tc.data).BinarySearch(tc.target)
+		if tc.wantPos != pos || tc.wantFound != found {
+			t.Errorf("%v.BinarySearch(%q) = %v, %v, want %v, %v", tc.data, tc.target, pos, found, tc.wantPos, tc.wantFound)
+		}
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	const n = 100_000
+	src := make([]int, n)
+	for i := range src {
+		src[i] = rand.Int()
+	}
+	data := make([]int, n)
+
+	b.Run("Sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, src)
+			Sort(data)
+		}
+	})
+
+	b.Run("SortFunc", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, src)
+			SortFunc(data, cmp[int])
+		}
+	})
+
+	b.Run("SortStableFunc", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(data, src)
+			SortStableFunc(data, cmp[int])
+		}
+	})
+}
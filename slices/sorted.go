@@ -0,0 +1,152 @@
+package slices
+
+import "golang.org/x/exp/constraints"
+
+// SortedSlice is a slice kept sorted in ascending order by a comparison function,
+// with lookups and updates using binary search.
+// Equal elements are kept in insertion order.
+// To create a SortedSlice use NewSortedSlice() or NewSortedSliceFunc().
+type SortedSlice[E any] struct {
+	s   []E
+	cmp func(a, b E) int
+}
+
+// NewSortedSlice creates and initializes a new SortedSlice using s, sorted,
+// as its initial contents. The new SortedSlice takes ownership of s, and the
+// caller should not use s after this call.
+// For floating-point numbers, NaNs are ordered before other values.
+func NewSortedSlice[E constraints.Ordered](s []E) *SortedSlice[E] {
+	return NewSortedSliceFunc(s, cmpCompare[E])
+}
+
+// NewSortedSliceFunc is like NewSortedSlice but uses a comparison function,
+// which must be a strict weak ordering as defined by SortFunc.
+func NewSortedSliceFunc[E any](s []E, cmp func(a, b E) int) *SortedSlice[E] {
+	SortStableFunc(s, cmp)
+	return &SortedSlice[E]{s, cmp}
+}
+
+// Len returns the number of elements in the sorted slice s.
+func (s *SortedSlice[E]) Len() int {
+	return len(s.s)
+}
+
+// At returns the i'th smallest element of the sorted slice s.
+// It panics if i is out of range.
+func (s *SortedSlice[E]) At(i int) E {
+	return s.s[i]
+}
+
+// Slice returns the elements of the sorted slice s in ascending order.
+// The result shares the underlying array of s and is valid until the next update;
+// the caller must not modify it.
+func (s *SortedSlice[E]) Slice() []E {
+	return s.s
+}
+
+// Insert inserts v into the sorted slice s after the elements equal to v,
+// and returns the index of v.
+func (s *SortedSlice[E]) Insert(v E) int {
+	i := s.upperBound(v)
+	s.s = Insert(s.s, i, v)
+	return i
+}
+
+// Delete removes the first element of the sorted slice s equal to v,
+// and reports whether there was one.
+func (s *SortedSlice[E]) Delete(v E) bool {
+	i := s.Index(v)
+	if i < 0 {
+		return false
+	}
+
+	s.s = Delete(s.s, i, i+1)
+	return true
+}
+
+// DeleteAt removes the element at index i from the sorted slice s.
+// It panics if i is out of range.
+func (s *SortedSlice[E]) DeleteAt(i int) {
+	s.s = Delete(s.s, i, i+1)
+}
+
+// Index returns the index of the first element of the sorted slice s equal to v,
+// or -1 if not present.
+func (s *SortedSlice[E]) Index(v E) int {
+	if i, found := BinarySearchFunc(s.s, v, s.cmp); found {
+		return i
+	}
+	return -1
+}
+
+// Contains reports whether v is present in the sorted slice s.
+func (s *SortedSlice[E]) Contains(v E) bool {
+	_, found := BinarySearchFunc(s.s, v, s.cmp)
+	return found
+}
+
+// Rank returns the number of elements of the sorted slice s less than v,
+// that is the index at which v would be inserted before the elements equal to it.
+func (s *SortedSlice[E]) Rank(v E) int {
+	i, _ := BinarySearchFunc(s.s, v, s.cmp)
+	return i
+}
+
+// Floor returns the greatest element of the sorted slice s less than or equal to v,
+// and reports whether there is one. If several elements qualify, the last one is returned.
+func (s *SortedSlice[E]) Floor(v E) (E, bool) {
+	i := s.upperBound(v)
+	if i == 0 {
+		return *new(E), false
+	}
+	return s.s[i-1], true
+}
+
+// Ceiling returns the least element of the sorted slice s greater than or equal to v,
+// and reports whether there is one. If several elements qualify, the first one is returned.
+func (s *SortedSlice[E]) Ceiling(v E) (E, bool) {
+	i, _ := BinarySearchFunc(s.s, v, s.cmp)
+	if i == len(s.s) {
+		return *new(E), false
+	}
+	return s.s[i], true
+}
+
+// Range returns the elements e of the sorted slice s such that lo <= e < hi,
+// in ascending order. The result shares the underlying array of s,
+// but its capacity is clipped, so appending to it never modifies s.
+func (s *SortedSlice[E]) Range(lo, hi E) []E {
+	i, _ := BinarySearchFunc(s.s, lo, s.cmp)
+	j, _ := BinarySearchFunc(s.s, hi, s.cmp)
+	if j < i {
+		j = i
+	}
+	return Clip(s.s[i:j])
+}
+
+// Grow increases the capacity of the sorted slice s, if necessary,
+// to guarantee space for another n elements. It panics if n is negative.
+func (s *SortedSlice[E]) Grow(n int) {
+	s.s = Grow(s.s, n)
+}
+
+// Clip removes unused capacity from the sorted slice s.
+func (s *SortedSlice[E]) Clip() {
+	s.s = Clip(s.s)
+}
+
+// upperBound returns the index of the first element of s greater than v,
+// or len(s.s) if none are.
+func (s *SortedSlice[E]) upperBound(v E) int {
+	// Invariant: s.s[i-1] <= v, s.s[j] > v.
+	i, j := 0, len(s.s)
+	for i < j {
+		h := int(uint(i+j) >> 1) // avoid overflow when computing h
+		if s.cmp(s.s[h], v) <= 0 {
+			i = h + 1
+		} else {
+			j = h
+		}
+	}
+	return i
+}
@@ -0,0 +1,170 @@
+package slices_test
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+)
+
+func TestSortedSlice(t *testing.T) {
+	t.Parallel()
+
+	s := NewSortedSlice([]int{5, 1, 3})
+	if got := s.Slice(); !Equal(got, []int{1, 3, 5}) {
+		t.Errorf("NewSortedSlice([5 1 3]) = %v, want [1 3 5]", got)
+	}
+
+	if i := s.Insert(4); i != 2 {
+		t.Errorf("Insert(4) = %d, want 2", i)
+	}
+	if i := s.Insert(3); i != 2 {
+		t.Errorf("Insert(3) = %d, want 2", i)
+	}
+	if i := s.Insert(0); i != 0 {
+		t.Errorf("Insert(0) = %d, want 0", i)
+	}
+	if got := s.Slice(); !Equal(got, []int{0, 1, 3, 3, 4, 5}) || s.Len() != 6 {
+		t.Errorf("after inserts = %v, want [0 1 3 3 4 5]", got)
+	}
+
+	for _, tc := range []struct {
+		v              int
+		index, rank    int
+		floor, ceiling int
+		hasFloor, hasC bool
+	}{
+		{-1, -1, 0, 0, 0, false, true},
+		{0, 0, 0, 0, 0, true, true},
+		{2, -1, 2, 1, 3, true, true},
+		{3, 2, 2, 3, 3, true, true},
+		{5, 5, 5, 5, 5, true, true},
+		{6, -1, 6, 5, 0, true, false},
+	} {
+		if got := s.Index(tc.v); got != tc.index {
+			t.Errorf("Index(%d) = %d, want %d", tc.v, got, tc.index)
+		}
+		if got := s.Contains(tc.v); got != (tc.index >= 0) {
+			t.Errorf("Contains(%d) = %t, want %t", tc.v, got, tc.index >= 0)
+		}
+		if got := s.Rank(tc.v); got != tc.rank {
+			t.Errorf("Rank(%d) = %d, want %d", tc.v, got, tc.rank)
+		}
+		if got, ok := s.Floor(tc.v); got != tc.floor || ok != tc.hasFloor {
+			t.Errorf("Floor(%d) = %d, %t, want %d, %t", tc.v, got, ok, tc.floor, tc.hasFloor)
+		}
+		if got, ok := s.Ceiling(tc.v); got != tc.ceiling || ok != tc.hasC {
+			t.Errorf("Ceiling(%d) = %d, %t, want %d, %t", tc.v, got, ok, tc.ceiling, tc.hasC)
+		}
+	}
+
+	for _, tc := range []struct {
+		lo, hi int
+		want   []int
+	}{
+		{1, 4, []int{1, 3, 3}},
+		{3, 4, []int{3, 3}},
+		{-5, 10, []int{0, 1, 3, 3, 4, 5}},
+		{2, 3, []int{}},
+		{4, 1, []int{}},
+	} {
+		got := s.Range(tc.lo, tc.hi)
+		if !Equal(got, tc.want) {
+			t.Errorf("Range(%d, %d) = %v, want %v", tc.lo, tc.hi, got, tc.want)
+		}
+		if len(got) != cap(got) {
+			t.Errorf("Range(%d, %d) has capacity %d, want %d", tc.lo, tc.hi, cap(got), len(got))
+		}
+	}
+
+	if !s.Delete(3) || s.Delete(2) {
+		t.Errorf("Delete(3), Delete(2): want true, false")
+	}
+	s.DeleteAt(0)
+	if got := s.Slice(); !Equal(got, []int{1, 3, 4, 5}) {
+		t.Errorf("after deletes = %v, want [1 3 4 5]", got)
+	}
+
+	s.Grow(100)
+	if got := cap(s.Slice()); got < 104 {
+		t.Errorf("cap after Grow(100) = %d, want at least 104", got)
+	}
+	s.Clip()
+	if got := cap(s.Slice()); got != 4 {
+		t.Errorf("cap after Clip() = %d, want 4", got)
+	}
+	if got := s.At(1); got != 3 {
+		t.Errorf("At(1) = %d, want 3", got)
+	}
+}
+
+func TestSortedSliceFunc(t *testing.T) {
+	t.Parallel()
+
+	type item struct {
+		key string
+		seq int
+	}
+	compareKeys := func(a, b item) int { return strings.Compare(a.key, b.key) }
+
+	s := NewSortedSliceFunc([]item{{"b", 0}, {"a", 1}, {"b", 2}}, compareKeys)
+	s.Insert(item{"b", 3})
+	s.Insert(item{"a", 4})
+
+	want := []item{{"a", 1}, {"a", 4}, {"b", 0}, {"b", 2}, {"b", 3}}
+	if got := s.Slice(); !Equal(got, want) {
+		t.Errorf("SortedSlice = %v, want %v", got, want)
+	}
+
+	if got, _ := s.Floor(item{key: "b"}); got.seq != 3 {
+		t.Errorf("Floor(b) = %v, want the last b", got)
+	}
+	if got, _ := s.Ceiling(item{key: "b"}); got.seq != 0 {
+		t.Errorf("Ceiling(b) = %v, want the first b", got)
+	}
+
+	s.Delete(item{key: "b"})
+	if got := s.Range(item{key: "b"}, item{key: "c"}); len(got) != 2 || got[0].seq != 2 {
+		t.Errorf("after Delete(b), Range(b, c) = %v, want [{b 2} {b 3}]", got)
+	}
+}
+
+func TestSortedSlice_Random(t *testing.T) {
+	t.Parallel()
+
+	s := NewSortedSlice[int](nil)
+	var model []int
+	for i := 0; i < 1000; i++ {
+		v := rand.Intn(50)
+		if rand.Intn(3) == 0 {
+			j := Index(model, v)
+			if ok := s.Delete(v); ok != (j >= 0) {
+				t.Fatalf("Delete(%d) = %t, want %t", v, ok, j >= 0)
+			}
+			if j >= 0 {
+				model = Delete(model, j, j+1)
+			}
+		} else {
+			s.Insert(v)
+			model = append(model, v)
+			Sort(model)
+		}
+
+		if !Equal(s.Slice(), model) {
+			t.Fatalf("SortedSlice = %v, want %v", s.Slice(), model)
+		}
+	}
+}
+
+func BenchmarkSortedSlice_Insert(b *testing.B) {
+	values := sliceGenerator(10_000)
+
+	for i := 0; i < b.N; i++ {
+		s := NewSortedSlice[int](nil)
+		s.Grow(len(values))
+		for _, v := range values {
+			s.Insert(v)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/weiwenchen2022/utils/slices"
+)
+
+// Histogram counts values falling into consecutive bins.
+// Bin i covers the half-open interval [Edges[i], Edges[i+1]),
+// except the last bin, which also includes its upper edge.
+// To create a Histogram use NewHistogram() or NewHistogramEdges().
+type Histogram struct {
+	Edges  []float64
+	Counts []int
+}
+
+// NewHistogram returns a histogram of the elements of the slice s with bins
+// equal-width bins spanning from the minimum to the maximum element.
+// If all the elements are equal, the bins span from x-0.5 to x+0.5;
+// if s is empty, they span from 0 to 1. NaNs and infinities are ignored:
+// they do not affect the bins, and fall outside them.
+// It panics if bins < 1.
+func NewHistogram[S ~[]E, E Number](s S, bins int) *Histogram {
+	if bins < 1 {
+		panic("stats: number of bins cannot be less than 1")
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range s {
+		x := float64(v)
+		if math.IsInf(x, 0) {
+			continue
+		}
+		if x < lo {
+			lo = x
+		}
+		if x > hi {
+			hi = x
+		}
+	}
+	switch {
+	case lo > hi: // empty, or all NaN or infinite
+		lo, hi = 0, 1
+	case lo == hi:
+		lo, hi = lo-0.5, hi+0.5
+	}
+
+	edges := make([]float64, bins+1)
+	// Divide first, since hi-lo may overflow.
+	width := hi/float64(bins) - lo/float64(bins)
+	for i := range edges {
+		edges[i] = lo + float64(i)*width
+	}
+	edges[bins] = hi // avoid rounding error on the last edge
+
+	h := &Histogram{Edges: edges, Counts: make([]int, bins)}
+	for _, v := range s {
+		h.Add(float64(v))
+	}
+	return h
+}
+
+// NewHistogramEdges returns a histogram of the elements of the slice s
+// with the bins delimited by edges. Elements outside the edges and NaNs are ignored.
+// It panics if edges has fewer than 2 elements or is not strictly increasing.
+// The histogram keeps a copy of edges.
+func NewHistogramEdges[S ~[]E, E Number](s S, edges []float64) *Histogram {
+	if len(edges) < 2 {
+		panic("stats: histogram needs at least 2 edges")
+	}
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i-1] < edges[i]) {
+			panic("stats: histogram edges are not strictly increasing")
+		}
+	}
+
+	h := &Histogram{Edges: slices.Clone(edges), Counts: make([]int, len(edges)-1)}
+	for _, v := range s {
+		h.Add(float64(v))
+	}
+	return h
+}
+
+// Bin returns the index of the bin containing x, or -1 if x is outside the edges or NaN.
+func (h *Histogram) Bin(x float64) int {
+	last := len(h.Edges) - 1
+	if !(h.Edges[0] <= x && x <= h.Edges[last]) {
+		return -1
+	}
+	if x == h.Edges[last] {
+		return last - 1
+	}
+
+	i, found := slices.BinarySearch(h.Edges, x)
+	if !found {
+		i--
+	}
+	return i
+}
+
+// Add counts x in its bin, and reports whether x falls within the edges.
+func (h *Histogram) Add(x float64) bool {
+	i := h.Bin(x)
+	if i < 0 {
+		return false
+	}
+
+	h.Counts[i]++
+	return true
+}
+
+// Total returns the number of values counted by the histogram h.
+func (h *Histogram) Total() int {
+	n := 0
+	for _, c := range h.Counts {
+		n += c
+	}
+	return n
+}
@@ -0,0 +1,276 @@
+// Package stats defines various functions useful with slices of numbers.
+package stats
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/weiwenchen2022/utils/slices"
+	"golang.org/x/exp/constraints"
+)
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Sum returns the sum of the elements of the slice s, or 0 if s is empty.
+// Integers are accumulated exactly in 128 bits, so intermediate results never
+// overflow, and the sum is converted to float64 once at the end.
+// Floating-point numbers are accumulated using Kahan-Babuska summation,
+// which keeps the rounding error independent of len(s).
+func Sum[S ~[]E, E Number](s S) float64 {
+	if isFloat[E]() {
+		var k kahan
+		for _, v := range s {
+			k.add(float64(v))
+		}
+		return k.sum()
+	}
+	return sumInt(s).float64()
+}
+
+// SumInt returns the sum of the elements of the slice s, and reports whether
+// the sum can be represented by E. Intermediate results never overflow,
+// so the sum is exact even if a partial sum does not fit in E.
+func SumInt[S ~[]E, E constraints.Integer](s S) (E, bool) {
+	a := sumInt(s)
+	if isSigned[E]() {
+		r := E(int64(a.lo))
+		return r, a.hi == uint64(int64(a.lo)>>63) && int64(r) == int64(a.lo)
+	}
+	r := E(a.lo)
+	return r, a.hi == 0 && uint64(r) == a.lo
+}
+
+// Mean returns the arithmetic mean of the elements of the slice s,
+// or NaN if s is empty.
+func Mean[S ~[]E, E Number](s S) float64 {
+	if len(s) == 0 {
+		return math.NaN()
+	}
+	return Sum(s) / float64(len(s))
+}
+
+// Median returns the median of the elements of the slice s, that is the middle
+// element of the sorted slice, or the mean of the two middle elements if len(s)
+// is even. It returns NaN if s is empty or contains NaN.
+// Median does not modify s; it runs in O(len(s)) on average.
+func Median[S ~[]E, E Number](s S) float64 {
+	return Quantile(s, 0.5, Midpoint)
+}
+
+// Mode returns the most frequent elements of the slice s in ascending order,
+// or nil if s is empty. NaNs are ignored.
+func Mode[S ~[]E, E Number](s S) S {
+	counts := make(map[E]int)
+	max := 0
+	for _, v := range s {
+		if v != v { // NaN
+			continue
+		}
+
+		counts[v]++
+		if c := counts[v]; c > max {
+			max = c
+		}
+	}
+	if max == 0 {
+		return nil
+	}
+
+	var r S
+	for v, c := range counts {
+		if c == max {
+			r = append(r, v)
+		}
+	}
+	slices.Sort(r)
+	return r
+}
+
+// Variance returns the population variance of the elements of the slice s,
+// or NaN if s is empty.
+func Variance[S ~[]E, E Number](s S) float64 {
+	return variance(s, 0)
+}
+
+// SampleVariance returns the unbiased sample variance of the elements of the slice s,
+// that is the sum of squared deviations divided by len(s)-1.
+// It returns NaN if s has fewer than 2 elements.
+func SampleVariance[S ~[]E, E Number](s S) float64 {
+	return variance(s, 1)
+}
+
+// StdDev returns the population standard deviation of the elements of the slice s,
+// or NaN if s is empty.
+func StdDev[S ~[]E, E Number](s S) float64 {
+	return math.Sqrt(Variance(s))
+}
+
+// SampleStdDev returns the sample standard deviation of the elements of the slice s,
+// the square root of SampleVariance, or NaN if s has fewer than 2 elements.
+func SampleStdDev[S ~[]E, E Number](s S) float64 {
+	return math.Sqrt(SampleVariance(s))
+}
+
+// variance uses the corrected two-pass algorithm, which subtracts the mean
+// before squaring and compensates for the rounding error of the mean.
+func variance[S ~[]E, E Number](s S, ddof int) float64 {
+	n := len(s)
+	if n <= ddof {
+		return math.NaN()
+	}
+
+	mean := Mean(s)
+	var ss, c kahan
+	for _, v := range s {
+		d := float64(v) - mean
+		ss.add(d * d)
+		c.add(d)
+	}
+
+	comp := c.sum()
+	return (ss.sum() - comp*comp/float64(n)) / float64(n-ddof)
+}
+
+// Interpolation specifies how Quantile computes a quantile
+// that lies between two elements of the sorted data.
+type Interpolation int
+
+const (
+	// Linear interpolates linearly between the two elements.
+	Linear Interpolation = iota
+	// Lower returns the lower element.
+	Lower
+	// Higher returns the higher element.
+	Higher
+	// Nearest returns the nearest element, or the even-indexed one if both are equally near.
+	Nearest
+	// Midpoint returns the mean of the two elements.
+	Midpoint
+)
+
+// Quantile returns the q-quantile of the elements of the slice s, for 0 <= q <= 1.
+// The quantile lies at position q*(len(s)-1) of the sorted slice; if that is
+// not an integer, method specifies how the two neighboring elements are combined.
+// It returns NaN if s is empty or contains NaN, and panics if q is out of range.
+// Quantile does not modify s; it runs in O(len(s)) on average.
+func Quantile[S ~[]E, E Number](s S, q float64, method Interpolation) float64 {
+	if !(0 <= q && q <= 1) {
+		panic("stats: quantile out of range")
+	}
+	if len(s) == 0 {
+		return math.NaN()
+	}
+
+	c := slices.Clone(s)
+	for _, v := range c {
+		if v != v { // NaN
+			return math.NaN()
+		}
+	}
+
+	h := q * float64(len(c)-1)
+	i := int(h)
+	slices.NthElement(c, i)
+	lo := float64(c[i])
+	if float64(i) == h {
+		return lo
+	}
+
+	// After NthElement, the next element in order is the minimum of the rest.
+	hi := float64(slices.Min(c[i+1:]...))
+	switch method {
+	case Linear:
+		return lo + (h-float64(i))*(hi-lo)
+	case Lower:
+		return lo
+	case Higher:
+		return hi
+	case Nearest:
+		if math.RoundToEven(h) == float64(i) {
+			return lo
+		}
+		return hi
+	case Midpoint:
+		return lo + (hi-lo)/2
+	default:
+		panic("stats: invalid interpolation method")
+	}
+}
+
+// Percentile returns the p-th percentile of the elements of the slice s,
+// for 0 <= p <= 100. It is equivalent to Quantile(s, p/100, method).
+func Percentile[S ~[]E, E Number](s S, p float64, method Interpolation) float64 {
+	if !(0 <= p && p <= 100) {
+		panic("stats: percentile out of range")
+	}
+	return Quantile(s, p/100, method)
+}
+
+// kahan accumulates float64 values using Kahan-Babuska (Neumaier) summation.
+type kahan struct {
+	s, c float64
+}
+
+func (k *kahan) add(v float64) {
+	t := k.s + v
+	if math.Abs(k.s) >= math.Abs(v) {
+		k.c += (k.s - t) + v
+	} else {
+		k.c += (v - t) + k.s
+	}
+	k.s = t
+}
+
+func (k *kahan) sum() float64 {
+	return k.s + k.c
+}
+
+// int128 is a two's complement 128-bit integer.
+type int128 struct {
+	hi, lo uint64
+}
+
+// sumInt returns the exact sum of the elements of the slice s,
+// which must be integers.
+func sumInt[S ~[]E, E Number](s S) int128 {
+	var a int128
+	var carry uint64
+	if isSigned[E]() {
+		for _, v := range s {
+			x := int64(v)
+			a.lo, carry = bits.Add64(a.lo, uint64(x), 0)
+			a.hi += uint64(x>>63) + carry // sign extension
+		}
+	} else {
+		for _, v := range s {
+			a.lo, carry = bits.Add64(a.lo, uint64(v), 0)
+			a.hi += carry
+		}
+	}
+	return a
+}
+
+func (a int128) float64() float64 {
+	if int64(a.hi) < 0 {
+		lo, borrow := bits.Sub64(0, a.lo, 0)
+		hi := -a.hi - borrow
+		return -(float64(hi)*0x1p64 + float64(lo))
+	}
+	return float64(a.hi)*0x1p64 + float64(a.lo)
+}
+
+// isFloat reports whether E is a floating-point type.
+func isFloat[E Number]() bool {
+	var x E = 1
+	return x/2 != 0
+}
+
+// isSigned reports whether E is a signed type.
+func isSigned[E Number]() bool {
+	var x E
+	x--
+	return x < 0
+}
@@ -0,0 +1,300 @@
+package stats_test
+
+import (
+	"math"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/slices"
+	. "github.com/weiwenchen2022/utils/stats"
+)
+
+func equalFloat(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return a == b || math.Abs(a-b) <= 1e-9*math.Max(math.Abs(a), math.Abs(b))
+}
+
+func TestSum(t *testing.T) {
+	t.Parallel()
+
+	if got := Sum([]int(nil)); got != 0 {
+		t.Errorf("Sum(nil) = %v, want 0", got)
+	}
+	if got := Sum([]int{1, 2, 3, -4}); got != 2 {
+		t.Errorf("Sum([1 2 3 -4]) = %v, want 2", got)
+	}
+
+	// The partial sums overflow int8, but the total does not.
+	s8 := []int8{100, 100, -100, -90}
+	if got := Sum(s8); got != 10 {
+		t.Errorf("Sum(%v) = %v, want 10", s8, got)
+	}
+	if got, ok := SumInt(s8); got != 10 || !ok {
+		t.Errorf("SumInt(%v) = %v, %t, want 10, true", s8, got, ok)
+	}
+	if got, ok := SumInt([]int8{100, 100}); ok {
+		t.Errorf("SumInt([100 100]) = %v, %t, want false", got, ok)
+	}
+	if got, ok := SumInt([]int8{-100, -100}); ok {
+		t.Errorf("SumInt([-100 -100]) = %v, %t, want false", got, ok)
+	}
+	if got, ok := SumInt([]uint8{200, 55}); got != 255 || !ok {
+		t.Errorf("SumInt([200 55]) = %v, %t, want 255, true", got, ok)
+	}
+	if got, ok := SumInt([]uint8{200, 56}); ok {
+		t.Errorf("SumInt([200 56]) = %v, %t, want false", got, ok)
+	}
+
+	big := []int64{math.MaxInt64, math.MaxInt64, math.MaxInt64}
+	if got, want := Sum(big), 3*float64(math.MaxInt64); !equalFloat(got, want) {
+		t.Errorf("Sum(%v) = %v, want %v", big, got, want)
+	}
+	neg := []int64{math.MinInt64, math.MinInt64, 1}
+	if got, want := Sum(neg), 2*float64(math.MinInt64); !equalFloat(got, want) {
+		t.Errorf("Sum(%v) = %v, want %v", neg, got, want)
+	}
+	ubig := []uint64{math.MaxUint64, math.MaxUint64}
+	if got, want := Sum(ubig), 2*float64(math.MaxUint64); !equalFloat(got, want) {
+		t.Errorf("Sum(%v) = %v, want %v", ubig, got, want)
+	}
+
+	// Naive summation gives 2 or 0 here.
+	f := []float64{1, 1e100, 1, -1e100}
+	if got := Sum(f); got != 2 {
+		t.Errorf("Sum(%v) = %v, want 2", f, got)
+	}
+	tenth := Repeat(0.1, 10)
+	if got := Sum(tenth); got != 1 {
+		t.Errorf("Sum(%v) = %v, want 1", tenth, got)
+	}
+	if got := Sum([]float32{1.5, 2.5}); got != 4 {
+		t.Errorf("Sum([1.5 2.5]) = %v, want 4", got)
+	}
+}
+
+var statsTests = []struct {
+	s                []float64
+	mean, median     float64
+	variance, sample float64
+}{
+	{nil, math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+	{[]float64{3}, 3, 3, 0, math.NaN()},
+	{[]float64{1, 2, 3, 4}, 2.5, 2.5, 1.25, 5.0 / 3},
+	{[]float64{2, 4, 4, 4, 5, 5, 7, 9}, 5, 4.5, 4, 32.0 / 7},
+	{[]float64{9, 1, 5}, 5, 5, 32.0 / 3, 16},
+	{[]float64{1, math.NaN()}, math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+}
+
+func TestMeanMedianVariance(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range statsTests {
+		if got := Mean(tc.s); !equalFloat(got, tc.mean) {
+			t.Errorf("Mean(%v) = %v, want %v", tc.s, got, tc.mean)
+		}
+		if got := Median(tc.s); !equalFloat(got, tc.median) {
+			t.Errorf("Median(%v) = %v, want %v", tc.s, got, tc.median)
+		}
+		if got := Variance(tc.s); !equalFloat(got, tc.variance) {
+			t.Errorf("Variance(%v) = %v, want %v", tc.s, got, tc.variance)
+		}
+		if got := SampleVariance(tc.s); !equalFloat(got, tc.sample) {
+			t.Errorf("SampleVariance(%v) = %v, want %v", tc.s, got, tc.sample)
+		}
+		if got, want := StdDev(tc.s), math.Sqrt(tc.variance); !equalFloat(got, want) {
+			t.Errorf("StdDev(%v) = %v, want %v", tc.s, got, want)
+		}
+		if got, want := SampleStdDev(tc.s), math.Sqrt(tc.sample); !equalFloat(got, want) {
+			t.Errorf("SampleStdDev(%v) = %v, want %v", tc.s, got, want)
+		}
+	}
+
+	// The variance must not suffer from catastrophic cancellation.
+	s := []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}
+	if got := Variance(s); got != 22.5 {
+		t.Errorf("Variance(%v) = %v, want 22.5", s, got)
+	}
+
+	ints := []int{5, 1, 4, 2, 3}
+	if got := Median(ints); got != 3 {
+		t.Errorf("Median(%v) = %v, want 3", ints, got)
+	}
+	if !Equal(ints, []int{5, 1, 4, 2, 3}) {
+		t.Errorf("Median modified the slice: %v", ints)
+	}
+	if got := Mean([]uint8{255, 255}); got != 255 {
+		t.Errorf("Mean([255 255]) = %v, want 255", got)
+	}
+}
+
+func TestMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		s, want []float64
+	}{
+		{nil, nil},
+		{[]float64{1}, []float64{1}},
+		{[]float64{3, 1, 3, 2}, []float64{3}},
+		{[]float64{3, 1, 2, 1, 3}, []float64{1, 3}},
+		{[]float64{math.NaN(), math.NaN(), 2}, []float64{2}},
+		{[]float64{math.NaN()}, nil},
+	}
+	for _, tc := range tests {
+		if got := Mode(tc.s); !Equal(got, tc.want) {
+			t.Errorf("Mode(%v) = %v, want %v", tc.s, got, tc.want)
+		}
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	t.Parallel()
+
+	s := []int{40, 10, 30, 20}
+	tests := []struct {
+		q      float64
+		method Interpolation
+		want   float64
+	}{
+		{0, Linear, 10},
+		{1, Linear, 40},
+		{0.5, Linear, 25},
+		{0.4, Linear, 22},
+		{0.4, Lower, 20},
+		{0.4, Higher, 30},
+		{0.4, Nearest, 20},
+		{0.5, Nearest, 30}, // position 1.5 rounds to the even index 2
+		{0.6, Nearest, 30},
+		{0.4, Midpoint, 25},
+		{0.75, Lower, 30},
+	}
+	for _, tc := range tests {
+		if got := Quantile(s, tc.q, tc.method); !equalFloat(got, tc.want) {
+			t.Errorf("Quantile(%v, %v, %v) = %v, want %v", s, tc.q, tc.method, got, tc.want)
+		}
+		if got := Percentile(s, tc.q*100, tc.method); !equalFloat(got, tc.want) {
+			t.Errorf("Percentile(%v, %v, %v) = %v, want %v", s, tc.q*100, tc.method, got, tc.want)
+		}
+	}
+
+	if got := Quantile([]int{}, 0.5, Linear); !math.IsNaN(got) {
+		t.Errorf("Quantile([], 0.5) = %v, want NaN", got)
+	}
+
+	for _, q := range []float64{-0.1, 1.1, math.NaN()} {
+		if !panics(func() { Quantile(s, q, Linear) }) {
+			t.Errorf("Quantile(%v, %v): got no panic, want panic", s, q)
+		}
+	}
+	if !panics(func() { Percentile(s, 101, Linear) }) {
+		t.Errorf("Percentile(%v, 101): got no panic, want panic", s)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	t.Parallel()
+
+	s := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, math.NaN()}
+	h := NewHistogram(s, 5)
+	if want := []float64{0, 2, 4, 6, 8, 10}; !Equal(h.Edges, want) {
+		t.Errorf("NewHistogram(%v, 5).Edges = %v, want %v", s, h.Edges, want)
+	}
+	if want := []int{2, 2, 2, 2, 3}; !Equal(h.Counts, want) {
+		t.Errorf("NewHistogram(%v, 5).Counts = %v, want %v", s, h.Counts, want)
+	}
+	if got := h.Total(); got != 11 {
+		t.Errorf("Total() = %v, want 11", got)
+	}
+	if h.Add(10.5) || h.Add(-1) {
+		t.Errorf("Add out of range: got true, want false")
+	}
+	if !h.Add(3.5) || h.Counts[1] != 3 {
+		t.Errorf("Add(3.5): Counts = %v, want Counts[1] = 3", h.Counts)
+	}
+
+	h = NewHistogram([]int{7, 7}, 2)
+	if want := []float64{6.5, 7, 7.5}; !Equal(h.Edges, want) {
+		t.Errorf("NewHistogram([7 7], 2).Edges = %v, want %v", h.Edges, want)
+	}
+	if want := []int{0, 2}; !Equal(h.Counts, want) {
+		t.Errorf("NewHistogram([7 7], 2).Counts = %v, want %v", h.Counts, want)
+	}
+
+	s = []float64{1, 2, math.Inf(1), 4, math.Inf(-1)}
+	h = NewHistogram(s, 3)
+	if want := []float64{1, 2, 3, 4}; !Equal(h.Edges, want) {
+		t.Errorf("NewHistogram(%v, 3).Edges = %v, want %v", s, h.Edges, want)
+	}
+	if want := []int{1, 1, 1}; !Equal(h.Counts, want) {
+		t.Errorf("NewHistogram(%v, 3).Counts = %v, want %v", s, h.Counts, want)
+	}
+
+	s = []float64{math.Inf(1), math.Inf(1)}
+	h = NewHistogram(s, 1)
+	if want := []float64{0, 1}; !Equal(h.Edges, want) || h.Total() != 0 {
+		t.Errorf("NewHistogram(%v, 1) = %+v, want empty [0, 1] histogram", s, h)
+	}
+
+	s = []float64{-math.MaxFloat64, 0, math.MaxFloat64}
+	h = NewHistogram(s, 2)
+	if want := []int{1, 2}; !Equal(h.Counts, want) {
+		t.Errorf("NewHistogram(%v, 2).Counts = %v, want %v", s, h.Counts, want)
+	}
+
+	h = NewHistogram([]int(nil), 1)
+	if want := []float64{0, 1}; !Equal(h.Edges, want) || h.Total() != 0 {
+		t.Errorf("NewHistogram(nil, 1) = %+v, want empty [0, 1] histogram", h)
+	}
+
+	edges := []float64{0, 1, 10, 100}
+	h = NewHistogramEdges([]int{-1, 0, 5, 50, 100, 1000}, edges)
+	if want := []int{1, 1, 2}; !Equal(h.Counts, want) {
+		t.Errorf("NewHistogramEdges(%v).Counts = %v, want %v", edges, h.Counts, want)
+	}
+	edges[0] = -5
+	if h.Edges[0] != 0 {
+		t.Errorf("NewHistogramEdges shares the edges")
+	}
+
+	if !panics(func() { NewHistogram([]int{1}, 0) }) {
+		t.Errorf("NewHistogram(0 bins): got no panic, want panic")
+	}
+	if !panics(func() { NewHistogramEdges([]int{1}, []float64{1}) }) {
+		t.Errorf("NewHistogramEdges(1 edge): got no panic, want panic")
+	}
+	if !panics(func() { NewHistogramEdges([]int{1}, []float64{1, 1}) }) {
+		t.Errorf("NewHistogramEdges(equal edges): got no panic, want panic")
+	}
+}
+
+func panics(f func()) (b bool) {
+	defer func() {
+		if x := recover(); x != nil {
+			b = true
+		}
+	}()
+	f()
+	return false
+}
+
+func BenchmarkSum(b *testing.B) {
+	ints := make([]int, 10_000)
+	floats := make([]float64, 10_000)
+	for i := range ints {
+		ints[i] = i
+		floats[i] = float64(i)
+	}
+
+	b.Run("int", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Sum(ints)
+		}
+	})
+
+	b.Run("float64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = Sum(floats)
+		}
+	})
+}
@@ -0,0 +1,216 @@
+package types
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// DeepCopy returns a deep copy of v.
+// Pointers, slices, maps, arrays, structs (including their unexported fields)
+// and interfaces are copied recursively; channels, functions and unsafe pointers
+// are copied using assignment.
+// If a type has a Clone method of the form "Clone() T", DeepCopy uses the result
+// of calling it instead of copying the value itself.
+// Values referenced several times, including through cycles, are copied once,
+// so the copy has the same shape as v.
+func DeepCopy[T any](v T) T {
+	c := deepCopier{visited: make(map[visit]reflect.Value)}
+
+	// The comma-ok form allows T to be an interface type and v to be nil.
+	r, _ := c.copy(reflect.ValueOf(&v).Elem()).Interface().(T)
+	return r
+}
+
+// visit identifies a pointer, map or slice already copied by a deepCopier.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+type deepCopier struct {
+	visited map[visit]reflect.Value
+}
+
+// copy returns a deep copy of src, which must not have been obtained
+// by accessing unexported struct fields.
+func (c *deepCopier) copy(src reflect.Value) reflect.Value {
+	t := src.Type()
+	info := typeInfoOf(t)
+
+	if info.clone && !isNil(src) {
+		return src.MethodByName("Clone").Call(nil)[0]
+	}
+	if info.shallow {
+		return src
+	}
+
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		k := visit{src.Pointer(), t, 0}
+		if dst, ok := c.visited[k]; ok {
+			return dst
+		}
+
+		dst := reflect.New(t.Elem())
+		c.visited[k] = dst
+		dst.Elem().Set(c.copy(src.Elem()))
+		return dst
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		dst := reflect.New(t).Elem()
+		dst.Set(c.copy(src.Elem()))
+		return dst
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		k := visit{src.Pointer(), t, src.Len()}
+		if dst, ok := c.visited[k]; ok {
+			return dst
+		}
+
+		dst := reflect.MakeSlice(t, src.Len(), src.Len())
+		c.visited[k] = dst
+		if typeInfoOf(t.Elem()).shallow {
+			reflect.Copy(dst, src)
+			return dst
+		}
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(c.copy(src.Index(i)))
+		}
+		return dst
+
+	case reflect.Map:
+		if src.IsNil() {
+			return reflect.Zero(t)
+		}
+
+		k := visit{src.Pointer(), t, 0}
+		if dst, ok := c.visited[k]; ok {
+			return dst
+		}
+
+		dst := reflect.MakeMapWithSize(t, src.Len())
+		c.visited[k] = dst
+		iter := src.MapRange()
+		for iter.Next() {
+			dst.SetMapIndex(c.copy(iter.Key()), c.copy(iter.Value()))
+		}
+		return dst
+
+	case reflect.Array:
+		dst := reflect.New(t).Elem()
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(c.copy(src.Index(i)))
+		}
+		return dst
+
+	case reflect.Struct:
+		if !src.CanAddr() {
+			// Unexported fields can only be accessed through their address.
+			tmp := reflect.New(t).Elem()
+			tmp.Set(src)
+			src = tmp
+		}
+
+		dst := reflect.New(t).Elem()
+		for i := 0; i < src.NumField(); i++ {
+			df, sf := dst.Field(i), src.Field(i)
+			if !df.CanSet() {
+				df, sf = unexported(df), unexported(sf)
+			}
+			df.Set(c.copy(sf))
+		}
+		return dst
+
+	default:
+		return src
+	}
+}
+
+// typeInfo describes how a deepCopier copies the values of a type.
+type typeInfo struct {
+	// clone reports whether the type has a Clone method used to copy its values.
+	clone bool
+
+	// shallow reports whether values of the type can be copied using assignment,
+	// because they reference no memory which must be copied and have no Clone method.
+	shallow bool
+}
+
+// typeInfoCache caches the result of typeInfoOf per type.
+var typeInfoCache sync.Map // map[reflect.Type]typeInfo
+
+// typeInfoOf returns the typeInfo of t.
+// The result is computed once per type and cached.
+func typeInfoOf(t reflect.Type) typeInfo {
+	if info, ok := typeInfoCache.Load(t); ok {
+		return info.(typeInfo)
+	}
+
+	var info typeInfo
+	info.clone = t.Kind() != reflect.Interface && hasCloneMethod(t)
+	info.shallow = !info.clone && typeIsShallow(t)
+	typeInfoCache.Store(t, info)
+	return info
+}
+
+// typeIsShallow reports whether the values of type t reference no memory
+// which DeepCopy copies, ignoring a Clone method of t itself.
+// Strings are immutable, and channels, functions and unsafe pointers are
+// copied using assignment, so they are shallow.
+func typeIsShallow(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return false
+	case reflect.Array:
+		return t.Len() == 0 || typeInfoOf(t.Elem()).shallow
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !typeInfoOf(t.Field(i).Type).shallow {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// hasCloneMethod reports whether t has a method of the form "Clone() t".
+func hasCloneMethod(t reflect.Type) bool {
+	m, ok := t.MethodByName("Clone")
+	if !ok {
+		return false
+	}
+
+	// The receiver is the first argument of m.Type.
+	mt := m.Type
+	return mt.NumIn() == 1 && mt.NumOut() == 1 && mt.Out(0) == t
+}
+
+// isNil reports whether v is nil, for the kinds of values that can be nil.
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Map, reflect.Pointer, reflect.Interface, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
+
+// unexported returns the addressable value v obtained by accessing an unexported
+// struct field as a value that can be read and set.
+func unexported(v reflect.Value) reflect.Value {
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
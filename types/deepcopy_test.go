@@ -0,0 +1,128 @@
+package types_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/weiwenchen2022/utils/types"
+)
+
+type node struct {
+	Name     string
+	next     *node
+	children []*node
+	attrs    map[string][]int
+}
+
+type cloneable struct {
+	n      int
+	cloned bool
+}
+
+func (c cloneable) Clone() cloneable {
+	return cloneable{c.n, true}
+}
+
+func TestDeepCopy(t *testing.T) {
+	t.Parallel()
+
+	if got := DeepCopy(23); got != 23 {
+		t.Errorf("DeepCopy(23) = %v, want 23", got)
+	}
+	if got := DeepCopy[any](nil); got != nil {
+		t.Errorf("DeepCopy[any](nil) = %v, want nil", got)
+	}
+	if got := DeepCopy([]int(nil)); got != nil {
+		t.Errorf("DeepCopy([]int(nil)) = %#v, want nil", got)
+	}
+
+	m := map[string][]int{"a": {1, 2}, "b": nil}
+	mc := DeepCopy(m)
+	if !reflect.DeepEqual(m, mc) {
+		t.Errorf("DeepCopy(%v) = %v, want %[1]v", m, mc)
+	}
+	mc["a"][0] = 10
+	if m["a"][0] != 1 {
+		t.Errorf("DeepCopy(%v) shares the slice values", m)
+	}
+
+	a := [2]*int{New(1), New(2)}
+	ac := DeepCopy(a)
+	if *ac[0] != 1 || *ac[1] != 2 || ac[0] == a[0] || ac[1] == a[1] {
+		t.Errorf("DeepCopy(%v) = %v, want a copy of the pointees", a, ac)
+	}
+
+	var i any = []any{map[int]*int{1: New(1)}}
+	ic := DeepCopy(i)
+	if !reflect.DeepEqual(i, ic) {
+		t.Errorf("DeepCopy(%v) = %v, want %[1]v", i, ic)
+	}
+	if i.([]any)[0].(map[int]*int)[1] == ic.([]any)[0].(map[int]*int)[1] {
+		t.Errorf("DeepCopy(%v) shares the pointer inside the interface", i)
+	}
+
+	c := func() {}
+	if got := DeepCopy(c); reflect.ValueOf(got).Pointer() != reflect.ValueOf(c).Pointer() {
+		t.Errorf("DeepCopy(func) returned a different function")
+	}
+}
+
+func TestDeepCopy_Unexported(t *testing.T) {
+	t.Parallel()
+
+	n := &node{Name: "root", attrs: map[string][]int{"x": {1}}}
+	n.children = []*node{{Name: "child"}}
+
+	nc := DeepCopy(n)
+	if !reflect.DeepEqual(n, nc) {
+		t.Errorf("DeepCopy(%v) = %v, want %[1]v", n, nc)
+	}
+	if nc == n || nc.children[0] == n.children[0] {
+		t.Errorf("DeepCopy(%v) shares pointers", n)
+	}
+
+	nc.attrs["x"][0] = 10
+	nc.children[0].Name = "changed"
+	if n.attrs["x"][0] != 1 || n.children[0].Name != "child" {
+		t.Errorf("DeepCopy(%v) shares the unexported fields", n)
+	}
+}
+
+func TestDeepCopy_Cycle(t *testing.T) {
+	t.Parallel()
+
+	n := &node{Name: "a"}
+	n.next = &node{Name: "b", next: n}
+	n.children = []*node{n, n.next}
+
+	nc := DeepCopy(n)
+	if nc == n || nc.next == n.next {
+		t.Fatalf("DeepCopy(%v) shares pointers", n)
+	}
+	if nc.next.next != nc {
+		t.Errorf("DeepCopy did not preserve the cycle")
+	}
+	if nc.children[0] != nc || nc.children[1] != nc.next {
+		t.Errorf("DeepCopy did not preserve the shared pointers")
+	}
+
+	s := make([]any, 1)
+	s[0] = s
+	sc := DeepCopy(s)
+	if reflect.ValueOf(sc[0]).Pointer() != reflect.ValueOf(sc).Pointer() {
+		t.Errorf("DeepCopy did not preserve the self-referencing slice")
+	}
+}
+
+func TestDeepCopy_Clone(t *testing.T) {
+	t.Parallel()
+
+	if got := DeepCopy(cloneable{n: 1}); !got.cloned || got.n != 1 {
+		t.Errorf("DeepCopy(cloneable) = %+v, want the result of Clone", got)
+	}
+
+	s := []struct{ C cloneable }{{cloneable{n: 2}}}
+	if got := DeepCopy(s); !got[0].C.cloned || got[0].C.n != 2 {
+		t.Errorf("DeepCopy(%+v) = %+v, want the result of Clone", s, got)
+	}
+}